@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/IvanGael/Go-CommandsSaver/internal/store"
+)
+
+// runAdd implements `cmdsaver add --tech=... --cmd=... --reason=...`.
+func runAdd(s store.Store, args []string) error {
+	fs := flag.NewFlagSet("add", flag.ContinueOnError)
+	tech := fs.String("tech", "", "technology the command belongs to")
+	cmdStr := fs.String("cmd", "", "the command itself")
+	reason := fs.String("reason", "", "why the command is useful")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *cmdStr == "" {
+		return fmt.Errorf("usage: cmdsaver add --tech=<technology> --cmd=<command> --reason=<reason>")
+	}
+
+	added, err := s.Add(*tech, *cmdStr, *reason, time.Now())
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Added command %d.\n", added.ID)
+	return nil
+}
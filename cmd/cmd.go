@@ -0,0 +1,54 @@
+// Package cmd implements cmdsaver's non-interactive subcommands (add,
+// list, export, rm, search, import, rekey, migrate) as well as the
+// original interactive menu, both driven through a store.Store so they
+// never touch a backend's underlying driver directly. migrate is the
+// exception: it's handled by main before a store.Store is opened, since
+// a migration always involves two databases rather than one.
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/IvanGael/Go-CommandsSaver/internal/store"
+)
+
+// Run parses args (typically os.Args[1:]) and dispatches to the
+// matching subcommand. With no args, it starts the interactive menu
+// instead, preserving the original CLI's behavior. It returns the
+// process exit code.
+func Run(s store.Store, args []string) int {
+	if len(args) == 0 {
+		runInteractive(s)
+		return 0
+	}
+
+	sub, rest := args[0], args[1:]
+
+	var err error
+	switch sub {
+	case "add":
+		err = runAdd(s, rest)
+	case "list":
+		err = runList(s, rest)
+	case "export":
+		err = runExport(s, rest)
+	case "rm":
+		err = runRm(s, rest)
+	case "search":
+		err = runSearch(s, rest)
+	case "import":
+		err = runImport(s, rest)
+	case "rekey":
+		err = runRekey(s, rest)
+	default:
+		fmt.Fprintf(os.Stderr, "cmdsaver: unknown subcommand %q\n", sub)
+		return 2
+	}
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "cmdsaver:", err)
+		return 1
+	}
+	return 0
+}
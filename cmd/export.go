@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/IvanGael/Go-CommandsSaver/internal/export"
+	"github.com/IvanGael/Go-CommandsSaver/internal/store"
+)
+
+// runExport implements `cmdsaver export --out=<file> [--redact]`,
+// choosing the export format from the destination file's extension.
+func runExport(s store.Store, args []string) error {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	out := fs.String("out", "", "destination file (.txt, .json, .csv, or .md)")
+	redactSecrets := fs.Bool("redact", false, "mask secret-shaped text (tokens, passwords) in the exported commands")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *out == "" {
+		return fmt.Errorf("usage: cmdsaver export --out=<file>")
+	}
+
+	commands, err := s.List()
+	if err != nil {
+		return err
+	}
+	if *redactSecrets {
+		commands = redactCommands(commands)
+	}
+
+	file, err := os.Create(*out)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if err := export.ForPath(*out).Export(file, toExportRecords(commands)); err != nil {
+		return err
+	}
+
+	fmt.Println("Commands exported to", *out)
+	return nil
+}
@@ -0,0 +1,19 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/IvanGael/Go-CommandsSaver/internal/store"
+)
+
+// runImport implements `cmdsaver import`, reading CSV rows or a JSON
+// array of commands from stdin (e.g. `history | cmdsaver import`).
+func runImport(s store.Store, args []string) error {
+	imported, errs := store.Import(s, os.Stdin)
+	for _, err := range errs {
+		fmt.Fprintln(os.Stderr, "import error:", err)
+	}
+	fmt.Printf("Imported %d command(s).\n", imported)
+	return nil
+}
@@ -0,0 +1,222 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/IvanGael/Go-CommandsSaver/internal/export"
+	"github.com/IvanGael/Go-CommandsSaver/internal/store"
+)
+
+// runInteractive drives the original menu-based CLI, now implemented in
+// terms of store.Store instead of *bolt.DB.
+func runInteractive(s store.Store) {
+	for {
+		fmt.Println("Choose an option:")
+		fmt.Println("1. Add a command")
+		fmt.Println("2. List all commands")
+		fmt.Println("3. Extract commands to file")
+		fmt.Println("4. Delete a command")
+		fmt.Println("5. Edit a command")
+		fmt.Println("6. Search commands")
+		fmt.Println("7. Import from stdin")
+		fmt.Println("8. Exit")
+
+		var choice string
+		fmt.Print("Enter your choice: ")
+		fmt.Scanln(&choice)
+
+		switch choice {
+		case "1":
+			addCommandInteractive(s)
+		case "2":
+			listCommandsInteractive(s)
+		case "3":
+			extractCommandsToFileInteractive(s)
+		case "4":
+			deleteCommandInteractive(s)
+		case "5":
+			editCommandInteractive(s)
+		case "6":
+			searchCommandsInteractive(s)
+		case "7":
+			importFromStdinInteractive(s)
+		case "8":
+			fmt.Println("Exiting...")
+			return
+		default:
+			fmt.Println("Invalid choice. Please enter a valid option.")
+		}
+	}
+}
+
+// addCommandInteractive adds a new command to the database interactively.
+func addCommandInteractive(s store.Store) {
+	reader := bufio.NewReader(os.Stdin)
+
+	fmt.Println("Enter the technology:")
+	technology, _ := reader.ReadString('\n')
+	technology = strings.TrimSpace(technology)
+
+	fmt.Println("Enter the command:")
+	command, _ := reader.ReadString('\n')
+	command = strings.TrimSpace(command)
+
+	fmt.Println("Enter the reason:")
+	reason, _ := reader.ReadString('\n')
+	reason = strings.TrimSpace(reason)
+
+	if _, err := s.Add(technology, command, reason, time.Now()); err != nil {
+		log.Println("Error adding command:", err)
+		return
+	}
+
+	fmt.Println("Command added successfully.")
+}
+
+// extractCommandsToFileInteractive extracts all commands from the
+// database to a file, choosing the export format (plain text, JSON,
+// CSV, or Markdown) from the file's extension.
+func extractCommandsToFileInteractive(s store.Store) {
+	reader := bufio.NewReader(os.Stdin)
+
+	fmt.Println("Enter the file path to save the commands (e.g., commands.txt, .json, .csv, or .md):")
+	filePath, _ := reader.ReadString('\n')
+	filePath = strings.TrimSpace(filePath)
+
+	file, err := os.Create(filePath)
+	if err != nil {
+		log.Println("Error creating file:", err)
+		return
+	}
+	defer file.Close()
+
+	commands, err := s.List()
+	if err != nil {
+		log.Println("Error getting commands:", err)
+		return
+	}
+
+	if err := export.ForPath(filePath).Export(file, toExportRecords(commands)); err != nil {
+		log.Println("Error exporting commands:", err)
+		return
+	}
+
+	fmt.Println("Commands extracted to", filePath, "successfully.")
+}
+
+// listCommandsInteractive retrieves and lists all commands from the database.
+func listCommandsInteractive(s store.Store) {
+	commands, err := s.List()
+	if err != nil {
+		log.Println("Error listing commands:", err)
+		return
+	}
+	printTable(commands)
+}
+
+// deleteCommandInteractive removes a command from the database by ID.
+func deleteCommandInteractive(s store.Store) {
+	reader := bufio.NewReader(os.Stdin)
+
+	fmt.Println("Enter the ID of the command to delete:")
+	idInput, _ := reader.ReadString('\n')
+	id, err := strconv.Atoi(strings.TrimSpace(idInput))
+	if err != nil {
+		fmt.Println("Invalid ID:", err)
+		return
+	}
+
+	if err := s.Delete(id); err != nil {
+		log.Println("Error deleting command:", err)
+		return
+	}
+
+	fmt.Println("Command deleted successfully.")
+}
+
+// editCommandInteractive replaces the fields of an existing command.
+func editCommandInteractive(s store.Store) {
+	reader := bufio.NewReader(os.Stdin)
+
+	fmt.Println("Enter the ID of the command to edit:")
+	idInput, _ := reader.ReadString('\n')
+	id, err := strconv.Atoi(strings.TrimSpace(idInput))
+	if err != nil {
+		fmt.Println("Invalid ID:", err)
+		return
+	}
+
+	fmt.Println("Enter the new technology:")
+	technology, _ := reader.ReadString('\n')
+	technology = strings.TrimSpace(technology)
+
+	fmt.Println("Enter the new command:")
+	command, _ := reader.ReadString('\n')
+	command = strings.TrimSpace(command)
+
+	fmt.Println("Enter the new reason:")
+	reason, _ := reader.ReadString('\n')
+	reason = strings.TrimSpace(reason)
+
+	if err := s.Update(id, technology, command, reason, time.Now()); err != nil {
+		log.Println("Error editing command:", err)
+		return
+	}
+
+	fmt.Println("Command updated successfully.")
+}
+
+// searchCommandsInteractive prompts for a substring query and optional
+// technology/since filters, then prints the matching commands.
+func searchCommandsInteractive(s store.Store) {
+	reader := bufio.NewReader(os.Stdin)
+
+	fmt.Println("Enter a search query (substring match on technology/command/reason, blank for none):")
+	query, _ := reader.ReadString('\n')
+	query = strings.TrimSpace(query)
+
+	fmt.Println("Filter by technology (blank for none):")
+	tech, _ := reader.ReadString('\n')
+	tech = strings.TrimSpace(tech)
+
+	fmt.Println("Only show commands since (YYYY-MM-DD, blank for none):")
+	sinceInput, _ := reader.ReadString('\n')
+	sinceInput = strings.TrimSpace(sinceInput)
+
+	var since time.Time
+	if sinceInput != "" {
+		var err error
+		since, err = time.Parse("2006-01-02", sinceInput)
+		if err != nil {
+			fmt.Println("Invalid date:", err)
+			return
+		}
+	}
+
+	commands, err := s.Search(query, store.SearchFilters{Tech: tech, Since: since})
+	if err != nil {
+		log.Println("Error searching commands:", err)
+		return
+	}
+	printTable(commands)
+}
+
+// importFromStdinInteractive reads commands from standard input (CSV
+// rows of "technology,command,reason", or a JSON array of the same
+// fields) and inserts them, reporting per-line errors without aborting
+// the rest of the import.
+func importFromStdinInteractive(s store.Store) {
+	fmt.Println("Paste CSV rows (technology,command,reason) or a JSON array, then send EOF (Ctrl+D):")
+
+	imported, errs := store.Import(s, os.Stdin)
+	for _, err := range errs {
+		fmt.Println("Import error:", err)
+	}
+	fmt.Printf("Imported %d command(s).\n", imported)
+}
@@ -0,0 +1,26 @@
+package cmd
+
+import (
+	"flag"
+
+	"github.com/IvanGael/Go-CommandsSaver/internal/store"
+)
+
+// runList implements `cmdsaver list [--redact]`.
+func runList(s store.Store, args []string) error {
+	fs := flag.NewFlagSet("list", flag.ContinueOnError)
+	redactSecrets := fs.Bool("redact", false, "mask secret-shaped text (tokens, passwords) in the listed commands")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	commands, err := s.List()
+	if err != nil {
+		return err
+	}
+	if *redactSecrets {
+		commands = redactCommands(commands)
+	}
+	printTable(commands)
+	return nil
+}
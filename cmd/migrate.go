@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/IvanGael/Go-CommandsSaver/internal/backend"
+	"github.com/IvanGael/Go-CommandsSaver/internal/store"
+)
+
+// RunMigrate implements `cmdsaver migrate`, copying every record from
+// one backend's database to another's. Unlike the other subcommands it
+// opens its own two stores rather than operating on the one main.go
+// opens for commands.db, since a migration always involves a second
+// database.
+func RunMigrate(args []string) error {
+	fs := flag.NewFlagSet("migrate", flag.ContinueOnError)
+	fromBackend := fs.String("from-backend", backend.Default, "source backend (bolt, leveldb, or sqlite)")
+	fromPath := fs.String("from-path", "", "source database path")
+	fromPassphrase := fs.String("from-passphrase", "", "source database passphrase, if encrypted")
+	toBackend := fs.String("to-backend", backend.Default, "destination backend (bolt, leveldb, or sqlite)")
+	toPath := fs.String("to-path", "", "destination database path")
+	toPassphrase := fs.String("to-passphrase", "", "passphrase to encrypt the destination database with")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *fromPath == "" || *toPath == "" {
+		return fmt.Errorf("usage: cmdsaver migrate --from-backend=<b> --from-path=<f> --to-backend=<b> --to-path=<f>")
+	}
+
+	src, err := backend.Open(*fromBackend, *fromPath, *fromPassphrase, nil)
+	if err != nil {
+		return fmt.Errorf("open source: %w", err)
+	}
+	defer src.Close()
+
+	dst, err := backend.Open(*toBackend, *toPath, *toPassphrase, nil)
+	if err != nil {
+		return fmt.Errorf("open destination: %w", err)
+	}
+	defer dst.Close()
+
+	migrated, errs := store.Migrate(src, dst)
+	for _, err := range errs {
+		fmt.Fprintln(os.Stderr, "cmdsaver: migrate:", err)
+	}
+
+	fmt.Printf("Migrated %d commands from %s to %s.\n", migrated, *fromPath, *toPath)
+	return nil
+}
@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/IvanGael/Go-CommandsSaver/internal/store"
+)
+
+// runRekey implements `cmdsaver rekey [--passphrase=<new>]`, changing or
+// removing the passphrase protecting the database's encryption. Passing
+// an empty (or omitted) --passphrase disables encryption.
+func runRekey(s store.Store, args []string) error {
+	fs := flag.NewFlagSet("rekey", flag.ContinueOnError)
+	passphrase := fs.String("passphrase", "", "new passphrase, or empty to disable encryption")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	rk, ok := s.(store.Rekeyer)
+	if !ok {
+		return fmt.Errorf("cmdsaver: this store backend does not support rekeying")
+	}
+	if err := rk.Rekey(*passphrase); err != nil {
+		return err
+	}
+
+	if *passphrase == "" {
+		fmt.Println("Encryption disabled.")
+	} else {
+		fmt.Println("Database rekeyed.")
+	}
+	return nil
+}
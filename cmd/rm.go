@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/IvanGael/Go-CommandsSaver/internal/store"
+)
+
+// runRm implements `cmdsaver rm <id>`.
+func runRm(s store.Store, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: cmdsaver rm <id>")
+	}
+
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid id %q: %w", args[0], err)
+	}
+
+	if err := s.Delete(id); err != nil {
+		return err
+	}
+
+	fmt.Printf("Deleted command %d.\n", id)
+	return nil
+}
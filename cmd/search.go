@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/IvanGael/Go-CommandsSaver/internal/store"
+)
+
+// runSearch implements `cmdsaver search [--tech=...] [--since=YYYY-MM-DD] <query>`.
+func runSearch(s store.Store, args []string) error {
+	fs := flag.NewFlagSet("search", flag.ContinueOnError)
+	tech := fs.String("tech", "", "filter by technology")
+	since := fs.String("since", "", "only show commands added on or after this date (YYYY-MM-DD)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	filters := store.SearchFilters{Tech: *tech}
+	if *since != "" {
+		t, err := time.Parse("2006-01-02", *since)
+		if err != nil {
+			return fmt.Errorf("invalid --since date: %w", err)
+		}
+		filters.Since = t
+	}
+
+	query := strings.Join(fs.Args(), " ")
+
+	commands, err := s.Search(query, filters)
+	if err != nil {
+		return err
+	}
+	printTable(commands)
+	return nil
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/IvanGael/Go-CommandsSaver/internal/export"
+	"github.com/IvanGael/Go-CommandsSaver/internal/redact"
+	"github.com/IvanGael/Go-CommandsSaver/internal/store"
+	"github.com/olekukonko/tablewriter"
+)
+
+// printTable renders commands as a table on stdout, matching the
+// original interactive listing's layout.
+func printTable(commands []store.Command) {
+	if len(commands) == 0 {
+		fmt.Println("No commands found.")
+		return
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"ID", "Technology", "Command", "Reason", "Date Added"})
+	for _, cmd := range commands {
+		table.Append([]string{
+			strconv.Itoa(cmd.ID), cmd.Technology, cmd.Command, cmd.Reason, cmd.DateAdded.Format("2006-01-02 15:04:05"),
+		})
+	}
+	table.Render()
+}
+
+// redactCommands returns a copy of commands with secret-shaped text in
+// the Command and Reason fields masked, for display or export with
+// --redact.
+func redactCommands(commands []store.Command) []store.Command {
+	redacted := make([]store.Command, len(commands))
+	for i, cmd := range commands {
+		masked := redact.Strings(redact.DefaultRules, cmd.Command, cmd.Reason)
+		cmd.Command, cmd.Reason = masked[0], masked[1]
+		redacted[i] = cmd
+	}
+	return redacted
+}
+
+// toExportRecords converts stored commands to the export package's
+// record shape.
+func toExportRecords(commands []store.Command) []export.Record {
+	records := make([]export.Record, len(commands))
+	for i, cmd := range commands {
+		records[i] = export.Record{
+			ID:         cmd.ID,
+			Technology: cmd.Technology,
+			Command:    cmd.Command,
+			Reason:     cmd.Reason,
+			DateAdded:  cmd.DateAdded,
+		}
+	}
+	return records
+}
@@ -0,0 +1,41 @@
+// Package backend selects and opens a concrete store.Store
+// implementation by name, so main and the migrate subcommand don't need
+// to import every storage package themselves.
+package backend
+
+import (
+	"fmt"
+
+	"github.com/IvanGael/Go-CommandsSaver/internal/store"
+	"github.com/IvanGael/Go-CommandsSaver/internal/store/boltstore"
+	"github.com/IvanGael/Go-CommandsSaver/internal/store/leveldbstore"
+	"github.com/IvanGael/Go-CommandsSaver/internal/store/sqlitestore"
+)
+
+// Default is the backend used when neither --backend nor
+// CMDSAVER_BACKEND is set, preserving cmdsaver's original BoltDB
+// behavior.
+const Default = "bolt"
+
+// Open opens the named backend's database at path. passphrase and
+// promptFn configure at-rest encryption, which only boltstore currently
+// supports; a non-empty passphrase for any other backend is an error
+// rather than being silently ignored.
+func Open(name, path, passphrase string, promptFn func() string) (store.Store, error) {
+	switch name {
+	case "", Default:
+		return boltstore.Open(path, passphrase, promptFn)
+	case "leveldb":
+		if passphrase != "" {
+			return nil, fmt.Errorf("backend: leveldb does not support encryption")
+		}
+		return leveldbstore.Open(path)
+	case "sqlite":
+		if passphrase != "" {
+			return nil, fmt.Errorf("backend: sqlite does not support encryption")
+		}
+		return sqlitestore.Open(path)
+	default:
+		return nil, fmt.Errorf("backend: unknown backend %q (want bolt, leveldb, or sqlite)", name)
+	}
+}
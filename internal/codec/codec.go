@@ -0,0 +1,151 @@
+// Package codec encodes and decodes stored Command records.
+//
+// Records are stored as a 1-byte format version followed by a
+// version-specific payload. Version 1 is a length-prefixed binary
+// encoding (each string field is a uvarint length followed by its raw
+// bytes, with the timestamp as an int64 UnixNano) that is immune to the
+// comma- and newline-corruption the original comma-joined format
+// suffered from. Version 0 records predate the version byte entirely:
+// they are the legacy comma-joined text produced by the original
+// encodeCommand, and are only ever decoded, never written.
+package codec
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// Version identifies the on-disk encoding of a record.
+const (
+	VersionLegacy byte = 0
+	VersionBinary byte = 1
+)
+
+// Record is the set of fields persisted for a saved command.
+type Record struct {
+	ID         int
+	Technology string
+	Command    string
+	Reason     string
+	DateAdded  time.Time
+}
+
+// Encode serializes r using the current (version 1) binary format.
+func Encode(r Record) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte(VersionBinary)
+
+	var varintBuf [binary.MaxVarintLen64]byte
+
+	n := binary.PutUvarint(varintBuf[:], uint64(r.ID))
+	buf.Write(varintBuf[:n])
+
+	for _, s := range []string{r.Technology, r.Command, r.Reason} {
+		n := binary.PutUvarint(varintBuf[:], uint64(len(s)))
+		buf.Write(varintBuf[:n])
+		buf.WriteString(s)
+	}
+
+	var tsBuf [8]byte
+	binary.BigEndian.PutUint64(tsBuf[:], uint64(r.DateAdded.UnixNano()))
+	buf.Write(tsBuf[:])
+
+	return buf.Bytes(), nil
+}
+
+// Decode deserializes data into a Record, dispatching on the leading
+// version byte. It reports whether the record was read via the legacy
+// path, so callers can rewrite it in the current format on next update.
+func Decode(data []byte) (rec Record, legacy bool, err error) {
+	if len(data) == 0 {
+		return Record{}, false, fmt.Errorf("codec: empty record")
+	}
+
+	switch data[0] {
+	case VersionBinary:
+		rec, err = decodeBinary(data[1:])
+		return rec, false, err
+	default:
+		rec, err = decodeLegacy(data)
+		return rec, true, err
+	}
+}
+
+func decodeBinary(data []byte) (Record, error) {
+	r := bytes.NewReader(data)
+
+	id, err := binary.ReadUvarint(r)
+	if err != nil {
+		return Record{}, fmt.Errorf("codec: read id: %w", err)
+	}
+
+	fields := make([]string, 3)
+	for i := range fields {
+		s, err := readString(r)
+		if err != nil {
+			return Record{}, fmt.Errorf("codec: read field %d: %w", i, err)
+		}
+		fields[i] = s
+	}
+
+	var tsBuf [8]byte
+	if _, err := io.ReadFull(r, tsBuf[:]); err != nil {
+		return Record{}, fmt.Errorf("codec: read timestamp: %w", err)
+	}
+	ts := int64(binary.BigEndian.Uint64(tsBuf[:]))
+
+	return Record{
+		ID:         int(id),
+		Technology: fields[0],
+		Command:    fields[1],
+		Reason:     fields[2],
+		DateAdded:  time.Unix(0, ts).UTC(),
+	}, nil
+}
+
+func readString(r *bytes.Reader) (string, error) {
+	n, err := binary.ReadUvarint(r)
+	if err != nil {
+		return "", err
+	}
+	buf := make([]byte, n)
+	if n > 0 {
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return "", err
+		}
+	}
+	return string(buf), nil
+}
+
+// decodeLegacy decodes the original comma-joined format:
+// "id,technology,command,reason,date". It cannot round-trip fields
+// that themselves contain a comma or newline, which is why version 1
+// exists, but it must keep working so old databases remain readable.
+func decodeLegacy(data []byte) (Record, error) {
+	parts := bytes.SplitN(data, []byte(","), 5)
+	if len(parts) != 5 {
+		return Record{}, fmt.Errorf("codec: malformed legacy record")
+	}
+
+	id, err := strconv.Atoi(string(parts[0]))
+	if err != nil {
+		return Record{}, fmt.Errorf("codec: legacy id: %w", err)
+	}
+
+	date, err := time.Parse(time.RFC3339, string(parts[4]))
+	if err != nil {
+		return Record{}, fmt.Errorf("codec: legacy date: %w", err)
+	}
+
+	return Record{
+		ID:         id,
+		Technology: string(parts[1]),
+		Command:    string(parts[2]),
+		Reason:     string(parts[3]),
+		DateAdded:  date,
+	}, nil
+}
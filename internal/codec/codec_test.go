@@ -0,0 +1,109 @@
+package codec
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRoundTrip(t *testing.T) {
+	cases := []Record{
+		{
+			ID:         1,
+			Technology: "go",
+			Command:    "go build ./...",
+			Reason:     "compile the project",
+			DateAdded:  time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			ID:         2,
+			Technology: "bash",
+			Command:    "ls, -la",
+			Reason:     "list files, including hidden ones",
+			DateAdded:  time.Date(2024, 3, 2, 8, 30, 0, 0, time.UTC),
+		},
+		{
+			ID:         3,
+			Technology: "docker",
+			Command:    "docker run \\\n  -it ubuntu",
+			Reason:     "multi-line\ncommand with a trailing newline\n",
+			DateAdded:  time.Date(2024, 3, 3, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			ID:         4,
+			Technology: "日本語",
+			Command:    "echo \"héllo wörld\" 🚀",
+			Reason:     "UTF-8 technology, command, and reason",
+			DateAdded:  time.Date(2024, 3, 4, 10, 15, 0, 0, time.UTC),
+		},
+		{
+			ID:         5,
+			Technology: "",
+			Command:    "",
+			Reason:     "",
+			DateAdded:  time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, want := range cases {
+		encoded, err := Encode(want)
+		if err != nil {
+			t.Fatalf("Encode(%+v): %v", want, err)
+		}
+
+		got, legacy, err := Decode(encoded)
+		if err != nil {
+			t.Fatalf("Decode: %v", err)
+		}
+		if legacy {
+			t.Errorf("Decode reported legacy for a binary-encoded record")
+		}
+		if got.ID != want.ID || got.Technology != want.Technology ||
+			got.Command != want.Command || got.Reason != want.Reason ||
+			!got.DateAdded.Equal(want.DateAdded) {
+			t.Errorf("round trip mismatch:\n got  %+v\n want %+v", got, want)
+		}
+	}
+}
+
+func TestDecodeLegacy(t *testing.T) {
+	date := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	legacy := []byte("7,go,go test ./...,run the test suite," + date.Format(time.RFC3339))
+
+	got, isLegacy, err := Decode(legacy)
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if !isLegacy {
+		t.Errorf("expected legacy record to be flagged as legacy")
+	}
+
+	want := Record{ID: 7, Technology: "go", Command: "go test ./...", Reason: "run the test suite", DateAdded: date}
+	if got != want {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestDecodeEmpty(t *testing.T) {
+	if _, _, err := Decode(nil); err == nil {
+		t.Error("expected an error decoding an empty record")
+	}
+}
+
+func TestDecodeTruncatedBinaryRecord(t *testing.T) {
+	encoded, err := Encode(Record{
+		ID:         1,
+		Technology: "go",
+		Command:    "go build ./...",
+		Reason:     "compile",
+		DateAdded:  time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+
+	for cut := 1; cut < len(encoded); cut++ {
+		if _, _, err := Decode(encoded[:cut]); err == nil {
+			t.Errorf("Decode(encoded[:%d]): expected an error for a truncated record", cut)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+// Package crypto derives at-rest encryption keys from a user passphrase
+// and encrypts/decrypts values with the resulting key.
+package crypto
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"io"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// KDFParams holds the Argon2id parameters used to derive a key from a
+// passphrase, plus the random salt. They're persisted alongside the
+// database so it can be reopened with the same passphrase later.
+type KDFParams struct {
+	Time    uint32
+	Memory  uint32
+	Threads uint8
+	KeyLen  uint32
+	Salt    []byte
+}
+
+// DefaultKDFParams generates fresh Argon2id parameters for interactive
+// use (one pass over 64 MiB of memory with 4 threads, yielding a 32-byte
+// key) and a random 16-byte salt.
+func DefaultKDFParams() (KDFParams, error) {
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return KDFParams{}, err
+	}
+	return KDFParams{Time: 1, Memory: 64 * 1024, Threads: 4, KeyLen: 32, Salt: salt}, nil
+}
+
+// DeriveKey derives an AES-256 key from passphrase using Argon2id.
+func DeriveKey(passphrase string, params KDFParams) []byte {
+	return argon2.IDKey([]byte(passphrase), params.Salt, params.Time, params.Memory, uint8(params.Threads), params.KeyLen)
+}
+
+// Marshal encodes p as uvarint time, memory, and key length fields, a
+// single raw byte for threads, and the salt as a uvarint length followed
+// by its raw bytes, matching the style of internal/codec.
+func (p KDFParams) Marshal() []byte {
+	var buf bytes.Buffer
+	var tmp [binary.MaxVarintLen64]byte
+
+	n := binary.PutUvarint(tmp[:], uint64(p.Time))
+	buf.Write(tmp[:n])
+	n = binary.PutUvarint(tmp[:], uint64(p.Memory))
+	buf.Write(tmp[:n])
+	buf.WriteByte(p.Threads)
+	n = binary.PutUvarint(tmp[:], uint64(p.KeyLen))
+	buf.Write(tmp[:n])
+	n = binary.PutUvarint(tmp[:], uint64(len(p.Salt)))
+	buf.Write(tmp[:n])
+	buf.Write(p.Salt)
+
+	return buf.Bytes()
+}
+
+// UnmarshalKDFParams decodes a KDFParams previously written by Marshal.
+func UnmarshalKDFParams(data []byte) (KDFParams, error) {
+	r := bytes.NewReader(data)
+
+	timeCost, err := binary.ReadUvarint(r)
+	if err != nil {
+		return KDFParams{}, errors.New("crypto: read kdf time cost: " + err.Error())
+	}
+	memory, err := binary.ReadUvarint(r)
+	if err != nil {
+		return KDFParams{}, errors.New("crypto: read kdf memory: " + err.Error())
+	}
+	threads, err := r.ReadByte()
+	if err != nil {
+		return KDFParams{}, errors.New("crypto: read kdf threads: " + err.Error())
+	}
+	keyLen, err := binary.ReadUvarint(r)
+	if err != nil {
+		return KDFParams{}, errors.New("crypto: read kdf key length: " + err.Error())
+	}
+	saltLen, err := binary.ReadUvarint(r)
+	if err != nil {
+		return KDFParams{}, errors.New("crypto: read kdf salt length: " + err.Error())
+	}
+	salt := make([]byte, saltLen)
+	if _, err := io.ReadFull(r, salt); err != nil {
+		return KDFParams{}, errors.New("crypto: read kdf salt: " + err.Error())
+	}
+
+	return KDFParams{
+		Time:    uint32(timeCost),
+		Memory:  uint32(memory),
+		Threads: threads,
+		KeyLen:  uint32(keyLen),
+		Salt:    salt,
+	}, nil
+}
+
+// Cipher encrypts and decrypts values with AES-GCM under a single
+// derived key.
+type Cipher struct {
+	aead cipher.AEAD
+}
+
+// NewCipher builds a Cipher from an AES-256 key (as produced by DeriveKey).
+func NewCipher(key []byte) (*Cipher, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &Cipher{aead: aead}, nil
+}
+
+// Encrypt seals plaintext behind a fresh random nonce, which is
+// prepended to the returned ciphertext.
+func (c *Cipher) Encrypt(plaintext []byte) ([]byte, error) {
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	return c.aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+// Decrypt reads the leading nonce written by Encrypt and returns the
+// plaintext, or an error if data was tampered with or the key is wrong.
+func (c *Cipher) Decrypt(data []byte) ([]byte, error) {
+	n := c.aead.NonceSize()
+	if len(data) < n {
+		return nil, errors.New("crypto: ciphertext too short")
+	}
+	nonce, ciphertext := data[:n], data[n:]
+	return c.aead.Open(nil, nonce, ciphertext, nil)
+}
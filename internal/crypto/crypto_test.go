@@ -0,0 +1,93 @@
+package crypto
+
+import "testing"
+
+func TestDeriveKeyIsDeterministic(t *testing.T) {
+	params, err := DefaultKDFParams()
+	if err != nil {
+		t.Fatalf("DefaultKDFParams: %v", err)
+	}
+
+	k1 := DeriveKey("correct horse", params)
+	k2 := DeriveKey("correct horse", params)
+	if string(k1) != string(k2) {
+		t.Error("DeriveKey should be deterministic for the same passphrase and params")
+	}
+
+	k3 := DeriveKey("wrong passphrase", params)
+	if string(k1) == string(k3) {
+		t.Error("DeriveKey should differ for different passphrases")
+	}
+}
+
+func TestKDFParamsRoundTrip(t *testing.T) {
+	want, err := DefaultKDFParams()
+	if err != nil {
+		t.Fatalf("DefaultKDFParams: %v", err)
+	}
+
+	got, err := UnmarshalKDFParams(want.Marshal())
+	if err != nil {
+		t.Fatalf("UnmarshalKDFParams: %v", err)
+	}
+
+	if got.Time != want.Time || got.Memory != want.Memory || got.Threads != want.Threads ||
+		got.KeyLen != want.KeyLen || string(got.Salt) != string(want.Salt) {
+		t.Errorf("round trip mismatch:\n got  %+v\n want %+v", got, want)
+	}
+}
+
+func TestCipherEncryptDecryptRoundTrip(t *testing.T) {
+	params, err := DefaultKDFParams()
+	if err != nil {
+		t.Fatalf("DefaultKDFParams: %v", err)
+	}
+	key := DeriveKey("hunter2", params)
+
+	c, err := NewCipher(key)
+	if err != nil {
+		t.Fatalf("NewCipher: %v", err)
+	}
+
+	plaintext := []byte("go build ./... # contains no secrets, just a test string")
+	ciphertext, err := c.Encrypt(plaintext)
+	if err != nil {
+		t.Fatalf("Encrypt: %v", err)
+	}
+	if string(ciphertext) == string(plaintext) {
+		t.Error("Encrypt returned the plaintext unchanged")
+	}
+
+	got, err := c.Decrypt(ciphertext)
+	if err != nil {
+		t.Fatalf("Decrypt: %v", err)
+	}
+	if string(got) != string(plaintext) {
+		t.Errorf("Decrypt = %q, want %q", got, plaintext)
+	}
+}
+
+func TestCipherDecryptWrongKeyFails(t *testing.T) {
+	params, err := DefaultKDFParams()
+	if err != nil {
+		t.Fatalf("DefaultKDFParams: %v", err)
+	}
+
+	c1, err := NewCipher(DeriveKey("passphrase-one", params))
+	if err != nil {
+		t.Fatalf("NewCipher: %v", err)
+	}
+	c2, err := NewCipher(DeriveKey("passphrase-two", params))
+	if err != nil {
+		t.Fatalf("NewCipher: %v", err)
+	}
+
+	ciphertext, err := c1.Encrypt([]byte("secret"))
+	if err != nil {
+		t.Fatalf("Encrypt: %v", err)
+	}
+
+	if _, err := c2.Decrypt(ciphertext); err == nil {
+		t.Error("expected Decrypt with the wrong key to fail")
+	}
+}
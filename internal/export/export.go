@@ -0,0 +1,106 @@
+// Package export renders stored commands to a file in one of several
+// structured formats, selected by the destination file's extension.
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// Record is the set of fields written out for a single command.
+type Record struct {
+	ID         int
+	Technology string
+	Command    string
+	Reason     string
+	DateAdded  time.Time
+}
+
+// Exporter writes records to w in a particular format.
+type Exporter interface {
+	Export(w io.Writer, records []Record) error
+}
+
+// ForPath selects an Exporter based on the extension of path: ".json"
+// for JSON, ".csv" for CSV, ".md" for a Markdown table, and plain text
+// for anything else (including no extension), matching the format the
+// original extractCommandsToFile always produced.
+func ForPath(path string) Exporter {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return jsonExporter{}
+	case ".csv":
+		return csvExporter{}
+	case ".md":
+		return markdownExporter{}
+	default:
+		return textExporter{}
+	}
+}
+
+type textExporter struct{}
+
+func (textExporter) Export(w io.Writer, records []Record) error {
+	for _, r := range records {
+		_, err := fmt.Fprintf(w, "ID: %d, Technology: %s, Command: %s, Reason: %s, Date Added: %s\n",
+			r.ID, r.Technology, r.Command, r.Reason, r.DateAdded.Format("2006-01-02 15:04:05"))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type jsonExporter struct{}
+
+func (jsonExporter) Export(w io.Writer, records []Record) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(records)
+}
+
+type csvExporter struct{}
+
+func (csvExporter) Export(w io.Writer, records []Record) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"ID", "Technology", "Command", "Reason", "DateAdded"}); err != nil {
+		return err
+	}
+	for _, r := range records {
+		row := []string{strconv.Itoa(r.ID), r.Technology, r.Command, r.Reason, r.DateAdded.Format(time.RFC3339)}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+type markdownExporter struct{}
+
+func (markdownExporter) Export(w io.Writer, records []Record) error {
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"ID", "Technology", "Command", "Reason", "Date Added"})
+	table.SetAutoWrapText(false)
+	table.SetAutoFormatHeaders(false)
+	table.SetCenterSeparator("|")
+	table.SetColumnSeparator("|")
+	table.SetRowSeparator("-")
+	table.SetBorders(tablewriter.Border{Left: true, Top: false, Right: true, Bottom: false})
+
+	for _, r := range records {
+		table.Append([]string{
+			strconv.Itoa(r.ID), r.Technology, r.Command, r.Reason, r.DateAdded.Format("2006-01-02 15:04:05"),
+		})
+	}
+	table.Render()
+	return nil
+}
@@ -0,0 +1,63 @@
+package export
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testRecords() []Record {
+	return []Record{
+		{ID: 1, Technology: "go", Command: "go build ./...", Reason: "compile", DateAdded: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+}
+
+func TestForPathSelectsByExtension(t *testing.T) {
+	cases := map[string]Exporter{
+		"out.json": jsonExporter{},
+		"out.csv":  csvExporter{},
+		"out.md":   markdownExporter{},
+		"out.txt":  textExporter{},
+		"out":      textExporter{},
+	}
+	for path, want := range cases {
+		if got := ForPath(path); got != want {
+			t.Errorf("ForPath(%q) = %T, want %T", path, got, want)
+		}
+	}
+}
+
+func TestJSONExporter(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (jsonExporter{}).Export(&buf, testRecords()); err != nil {
+		t.Fatalf("Export: %v", err)
+	}
+	if !strings.Contains(buf.String(), `"Technology": "go"`) {
+		t.Errorf("JSON output missing technology field: %s", buf.String())
+	}
+}
+
+func TestCSVExporter(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (csvExporter{}).Export(&buf, testRecords()); err != nil {
+		t.Fatalf("Export: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected a header and one data row, got %d lines: %q", len(lines), buf.String())
+	}
+	if !strings.Contains(lines[1], "go build ./...") {
+		t.Errorf("CSV row missing command: %s", lines[1])
+	}
+}
+
+func TestMarkdownExporter(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (markdownExporter{}).Export(&buf, testRecords()); err != nil {
+		t.Fatalf("Export: %v", err)
+	}
+	if !strings.Contains(buf.String(), "|") {
+		t.Errorf("Markdown output missing table separators: %s", buf.String())
+	}
+}
@@ -0,0 +1,41 @@
+// Package redact masks secret-shaped substrings (tokens, passwords,
+// bearer headers) in displayed or exported command text.
+package redact
+
+import "regexp"
+
+// mask replaces a matched substring in output.
+const mask = "[REDACTED]"
+
+// Rule is a named regex whose matches get replaced with mask.
+type Rule struct {
+	Name    string
+	Pattern *regexp.Regexp
+}
+
+// DefaultRules covers the secret shapes most likely to show up in a
+// saved shell command: bearer tokens, "password=..."-style assignments,
+// and long opaque tokens (API keys, hex digests, base64 blobs).
+var DefaultRules = []Rule{
+	{Name: "bearer-header", Pattern: regexp.MustCompile(`(?i)bearer\s+[a-z0-9._-]+`)},
+	{Name: "key-value-secret", Pattern: regexp.MustCompile(`(?i)(password|passwd|pwd|secret|token|api[_-]?key)\s*[=:]\s*\S+`)},
+	{Name: "long-token", Pattern: regexp.MustCompile(`\b[A-Za-z0-9_-]{24,}\b`)},
+}
+
+// Apply replaces every match of every rule in s with mask.
+func Apply(s string, rules []Rule) string {
+	for _, r := range rules {
+		s = r.Pattern.ReplaceAllString(s, mask)
+	}
+	return s
+}
+
+// Strings applies Apply to each value and returns the masked results in
+// the same order.
+func Strings(rules []Rule, values ...string) []string {
+	out := make([]string, len(values))
+	for i, v := range values {
+		out[i] = Apply(v, rules)
+	}
+	return out
+}
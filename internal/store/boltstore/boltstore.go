@@ -0,0 +1,592 @@
+// Package boltstore persists store.Commands in BoltDB behind the
+// store.Store interface, so callers (the interactive menu, the CLI
+// subcommands, and tests) never touch *bolt.DB directly.
+package boltstore
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/IvanGael/Go-CommandsSaver/internal/codec"
+	"github.com/IvanGael/Go-CommandsSaver/internal/crypto"
+	"github.com/IvanGael/Go-CommandsSaver/internal/store"
+	"github.com/boltdb/bolt"
+)
+
+// Bucket names. by_technology and by_date are secondary indexes kept in
+// sync with commands inside the same Bolt transaction so lookups by
+// technology or date range don't require a full scan of commands. meta
+// holds the encryption header (KDF parameters and a passphrase check
+// value) and is never itself encrypted.
+var (
+	bucketCommands     = []byte("commands")
+	bucketByTechnology = []byte("by_technology")
+	bucketByDate       = []byte("by_date")
+	bucketMeta         = []byte("meta")
+)
+
+// Keys within bucketMeta. checkPlaintext is encrypted under the derived
+// key and stored at checkKey so a wrong passphrase can be rejected on
+// Open without attempting to decrypt any real record.
+var (
+	kdfParamsKey   = []byte("kdf_params")
+	checkKey       = []byte("check")
+	checkPlaintext = []byte("cmdsaver-check")
+)
+
+// Store is the BoltDB-backed store.Store implementation. cipher is nil
+// for a plaintext database; when set, every command value is encrypted
+// at rest while the by_technology and by_date index keys stay in
+// plaintext so they remain seekable.
+type Store struct {
+	db     *bolt.DB
+	cipher *crypto.Cipher
+}
+
+// Open opens (creating if necessary) a BoltDB database at path and
+// ensures the commands bucket and its secondary indexes exist.
+//
+// If the database already has an encryption header, passphrase must
+// decrypt its check value; an empty passphrase triggers a single call to
+// promptFn (which may be nil) to ask for one interactively. If the
+// database has no encryption header and no commands yet and passphrase
+// is non-empty, encryption is enabled for it. Enabling encryption on a
+// database that already holds unencrypted commands is rejected - use the
+// rekey subcommand instead, which can re-encrypt records already on disk.
+func Open(path string, passphrase string, promptFn func() string) (store.Store, error) {
+	db, err := bolt.Open(path, 0600, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Store{db: db}
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{bucketCommands, bucketByTechnology, bucketByDate} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		meta, err := tx.CreateBucketIfNotExists(bucketMeta)
+		if err != nil {
+			return err
+		}
+
+		params := meta.Get(kdfParamsKey)
+		switch {
+		case params != nil:
+			if passphrase == "" {
+				if promptFn == nil {
+					return fmt.Errorf("boltstore: %s is encrypted; a passphrase is required", path)
+				}
+				passphrase = promptFn()
+			}
+			kdf, err := crypto.UnmarshalKDFParams(params)
+			if err != nil {
+				return fmt.Errorf("boltstore: read encryption header: %w", err)
+			}
+			c, err := crypto.NewCipher(crypto.DeriveKey(passphrase, kdf))
+			if err != nil {
+				return err
+			}
+			if _, err := c.Decrypt(meta.Get(checkKey)); err != nil {
+				return fmt.Errorf("boltstore: wrong passphrase")
+			}
+			s.cipher = c
+		case passphrase != "":
+			if b := tx.Bucket(bucketCommands); b.Stats().KeyN > 0 {
+				return fmt.Errorf("boltstore: %s already has unencrypted commands; use the rekey subcommand to enable encryption", path)
+			}
+			kdf, err := crypto.DefaultKDFParams()
+			if err != nil {
+				return err
+			}
+			c, err := crypto.NewCipher(crypto.DeriveKey(passphrase, kdf))
+			if err != nil {
+				return err
+			}
+			check, err := c.Encrypt(checkPlaintext)
+			if err != nil {
+				return err
+			}
+			if err := meta.Put(kdfParamsKey, kdf.Marshal()); err != nil {
+				return err
+			}
+			if err := meta.Put(checkKey, check); err != nil {
+				return err
+			}
+			s.cipher = c
+		}
+		return reindexIfNeeded(tx, s)
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Close releases the underlying database handle.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Add adds a new command to the database.
+func (s *Store) Add(technology, command, reason string, date time.Time) (store.Command, error) {
+	var cmd store.Command
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucketCommands)
+		id, _ := b.NextSequence()
+		cmd = store.Command{
+			ID:         int(id),
+			Technology: technology,
+			Command:    command,
+			Reason:     reason,
+			DateAdded:  date,
+		}
+		encoded, err := s.encodeCommand(cmd)
+		if err != nil {
+			return err
+		}
+		if err := b.Put(itob(cmd.ID), encoded); err != nil {
+			return err
+		}
+		return putIndexes(tx, cmd)
+	})
+	if err != nil {
+		return store.Command{}, err
+	}
+	return cmd, nil
+}
+
+// Get retrieves a single command by ID.
+func (s *Store) Get(id int) (store.Command, error) {
+	var cmd store.Command
+	err := s.db.View(func(tx *bolt.Tx) error {
+		v := tx.Bucket(bucketCommands).Get(itob(id))
+		if v == nil {
+			return fmt.Errorf("no command with id %d", id)
+		}
+		var err error
+		cmd, _, err = s.decodeCommand(v)
+		return err
+	})
+	if err != nil {
+		return store.Command{}, err
+	}
+	return cmd, nil
+}
+
+// List retrieves all commands from the database.
+func (s *Store) List() ([]store.Command, error) {
+	var commands []store.Command
+	err := s.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucketCommands)
+		c := b.Cursor()
+
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			cmd, _, err := s.decodeCommand(v)
+			if err != nil {
+				return err
+			}
+			commands = append(commands, cmd)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return commands, nil
+}
+
+// Delete removes a command and its secondary index entries in a single
+// transaction.
+func (s *Store) Delete(id int) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucketCommands)
+		v := b.Get(itob(id))
+		if v == nil {
+			return fmt.Errorf("no command with id %d", id)
+		}
+		cmd, _, err := s.decodeCommand(v)
+		if err != nil {
+			return err
+		}
+
+		if err := b.Delete(itob(id)); err != nil {
+			return err
+		}
+		return deleteIndexes(tx, cmd)
+	})
+}
+
+// Update replaces the technology, command, reason, and date of an
+// existing command, keeping the secondary indexes in sync with the new
+// values. Reading the old record through decodeCommand also migrates a
+// legacy-encoded record to the current codec format as a side effect of
+// the rewrite.
+func (s *Store) Update(id int, technology, command, reason string, date time.Time) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucketCommands)
+		v := b.Get(itob(id))
+		if v == nil {
+			return fmt.Errorf("no command with id %d", id)
+		}
+		old, _, err := s.decodeCommand(v)
+		if err != nil {
+			return err
+		}
+
+		updated := store.Command{
+			ID:         id,
+			Technology: technology,
+			Command:    command,
+			Reason:     reason,
+			DateAdded:  date,
+		}
+		encoded, err := s.encodeCommand(updated)
+		if err != nil {
+			return err
+		}
+		if err := b.Put(itob(id), encoded); err != nil {
+			return err
+		}
+
+		if err := deleteIndexes(tx, old); err != nil {
+			return err
+		}
+		return putIndexes(tx, updated)
+	})
+}
+
+// Search returns the commands whose technology, command, or reason
+// contains query (case-insensitively), narrowed by filters. A non-empty
+// Tech or Since filter is served by a secondary index instead of a full
+// scan of the commands bucket.
+func (s *Store) Search(query string, filters store.SearchFilters) ([]store.Command, error) {
+	var results []store.Command
+	err := s.db.View(func(tx *bolt.Tx) error {
+		ids, err := candidateIDs(tx, filters)
+		if err != nil {
+			return err
+		}
+
+		b := tx.Bucket(bucketCommands)
+		query = strings.ToLower(query)
+		for _, id := range ids {
+			v := b.Get(itob(id))
+			if v == nil {
+				continue
+			}
+			cmd, _, err := s.decodeCommand(v)
+			if err != nil {
+				return err
+			}
+			if !matchesFilters(cmd, filters) {
+				continue
+			}
+			if query != "" && !containsQuery(cmd, query) {
+				continue
+			}
+			results = append(results, cmd)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// candidateIDs narrows the set of IDs to inspect using the by_technology
+// and/or by_date indexes, falling back to every stored ID when neither
+// filter is set.
+func candidateIDs(tx *bolt.Tx, filters store.SearchFilters) ([]int, error) {
+	switch {
+	case filters.Tech != "" && !filters.Since.IsZero():
+		byTech := idSet(idsByTechnology(tx, filters.Tech))
+		var ids []int
+		for _, id := range idsSince(tx, filters.Since) {
+			if byTech[id] {
+				ids = append(ids, id)
+			}
+		}
+		return ids, nil
+	case filters.Tech != "":
+		return idsByTechnology(tx, filters.Tech), nil
+	case !filters.Since.IsZero():
+		return idsSince(tx, filters.Since), nil
+	default:
+		return allIDs(tx), nil
+	}
+}
+
+func idSet(ids []int) map[int]bool {
+	set := make(map[int]bool, len(ids))
+	for _, id := range ids {
+		set[id] = true
+	}
+	return set
+}
+
+func matchesFilters(cmd store.Command, filters store.SearchFilters) bool {
+	if filters.Tech != "" && cmd.Technology != filters.Tech {
+		return false
+	}
+	if !filters.Since.IsZero() && cmd.DateAdded.Before(filters.Since) {
+		return false
+	}
+	return true
+}
+
+func containsQuery(cmd store.Command, lowerQuery string) bool {
+	return strings.Contains(strings.ToLower(cmd.Technology), lowerQuery) ||
+		strings.Contains(strings.ToLower(cmd.Command), lowerQuery) ||
+		strings.Contains(strings.ToLower(cmd.Reason), lowerQuery)
+}
+
+// allIDs returns every ID in the commands bucket.
+func allIDs(tx *bolt.Tx) []int {
+	var ids []int
+	c := tx.Bucket(bucketCommands).Cursor()
+	for k, _ := c.First(); k != nil; k, _ = c.Next() {
+		ids = append(ids, int(binary.BigEndian.Uint64(k)))
+	}
+	return ids
+}
+
+// idsByTechnology scans the by_technology index for the given technology
+// using a prefix seek rather than a full scan of commands.
+func idsByTechnology(tx *bolt.Tx, technology string) []int {
+	var ids []int
+	prefix := technologyIndexPrefix(technology)
+	c := tx.Bucket(bucketByTechnology).Cursor()
+	for k, v := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
+		ids = append(ids, int(binary.BigEndian.Uint64(v)))
+	}
+	return ids
+}
+
+// idsSince scans the by_date index starting at since, again avoiding a
+// full scan of commands.
+func idsSince(tx *bolt.Tx, since time.Time) []int {
+	var ids []int
+	c := tx.Bucket(bucketByDate).Cursor()
+	for k, v := c.Seek(dateIndexSeekKey(since)); k != nil; k, v = c.Next() {
+		ids = append(ids, int(binary.BigEndian.Uint64(v)))
+	}
+	return ids
+}
+
+// reindexIfNeeded rebuilds the by_technology and by_date indexes from
+// the commands bucket whenever their entry counts don't match commands
+// - e.g. a database that predates the secondary indexes, or one that
+// still has legacy comma-encoded records that were never rewritten.
+// Without this, candidateIDs would silently serve an empty result for
+// an otherwise-valid Tech or Since filter on those records, even though
+// List shows them.
+func reindexIfNeeded(tx *bolt.Tx, s *Store) error {
+	commands := tx.Bucket(bucketCommands)
+	n := commands.Stats().KeyN
+	if tx.Bucket(bucketByTechnology).Stats().KeyN == n && tx.Bucket(bucketByDate).Stats().KeyN == n {
+		return nil
+	}
+
+	if err := clearBucket(tx, bucketByTechnology); err != nil {
+		return err
+	}
+	if err := clearBucket(tx, bucketByDate); err != nil {
+		return err
+	}
+
+	c := commands.Cursor()
+	for k, v := c.First(); k != nil; k, v = c.Next() {
+		cmd, _, err := s.decodeCommand(v)
+		if err != nil {
+			return err
+		}
+		if err := putIndexes(tx, cmd); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// clearBucket empties the named bucket by dropping and recreating it.
+func clearBucket(tx *bolt.Tx, name []byte) error {
+	if err := tx.DeleteBucket(name); err != nil {
+		return err
+	}
+	_, err := tx.CreateBucket(name)
+	return err
+}
+
+// putIndexes adds by_technology and by_date entries for cmd.
+func putIndexes(tx *bolt.Tx, cmd store.Command) error {
+	techIdx := tx.Bucket(bucketByTechnology)
+	if err := techIdx.Put(technologyIndexKey(cmd.Technology, cmd.ID), itob(cmd.ID)); err != nil {
+		return err
+	}
+	dateIdx := tx.Bucket(bucketByDate)
+	return dateIdx.Put(dateIndexKey(cmd.DateAdded, cmd.ID), itob(cmd.ID))
+}
+
+// deleteIndexes removes the by_technology and by_date entries for cmd.
+func deleteIndexes(tx *bolt.Tx, cmd store.Command) error {
+	techIdx := tx.Bucket(bucketByTechnology)
+	if err := techIdx.Delete(technologyIndexKey(cmd.Technology, cmd.ID)); err != nil {
+		return err
+	}
+	dateIdx := tx.Bucket(bucketByDate)
+	return dateIdx.Delete(dateIndexKey(cmd.DateAdded, cmd.ID))
+}
+
+// technologyIndexKey builds a by_technology key that sorts together by
+// technology and is unique per command ID.
+func technologyIndexKey(technology string, id int) []byte {
+	key := technologyIndexPrefix(technology)
+	return append(key, itob(id)...)
+}
+
+func technologyIndexPrefix(technology string) []byte {
+	return append([]byte(technology), 0x00)
+}
+
+// dateIndexKey builds a by_date key that sorts by timestamp and is
+// unique per command ID.
+func dateIndexKey(t time.Time, id int) []byte {
+	key := make([]byte, 16)
+	binary.BigEndian.PutUint64(key[:8], uint64(t.UnixNano()))
+	binary.BigEndian.PutUint64(key[8:], uint64(id))
+	return key
+}
+
+// dateIndexSeekKey builds the smallest by_date key at or after since, for
+// use with Cursor.Seek.
+func dateIndexSeekKey(since time.Time) []byte {
+	key := make([]byte, 16)
+	binary.BigEndian.PutUint64(key[:8], uint64(since.UnixNano()))
+	return key
+}
+
+// encodeCommand encodes a command using the current codec format,
+// encrypting the result if the store has an active cipher.
+func (s *Store) encodeCommand(cmd store.Command) ([]byte, error) {
+	encoded, err := codec.Encode(codec.Record{
+		ID:         cmd.ID,
+		Technology: cmd.Technology,
+		Command:    cmd.Command,
+		Reason:     cmd.Reason,
+		DateAdded:  cmd.DateAdded,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if s.cipher == nil {
+		return encoded, nil
+	}
+	return s.cipher.Encrypt(encoded)
+}
+
+// decodeCommand decrypts data (if the store has an active cipher) and
+// decodes it into a command, transparently supporting records stored
+// under the legacy comma-joined format. The returned bool reports
+// whether the record was read via that legacy path, so callers can
+// migrate it to the current format on next update.
+func (s *Store) decodeCommand(data []byte) (store.Command, bool, error) {
+	if s.cipher != nil {
+		plain, err := s.cipher.Decrypt(data)
+		if err != nil {
+			return store.Command{}, false, fmt.Errorf("boltstore: decrypt record: %w", err)
+		}
+		data = plain
+	}
+	rec, legacy, err := codec.Decode(data)
+	if err != nil {
+		return store.Command{}, false, err
+	}
+	return store.Command{
+		ID:         rec.ID,
+		Technology: rec.Technology,
+		Command:    rec.Command,
+		Reason:     rec.Reason,
+		DateAdded:  rec.DateAdded,
+	}, legacy, nil
+}
+
+// itob converts an integer to a byte slice.
+func itob(v int) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, uint64(v))
+	return b
+}
+
+// Rekey re-encrypts every stored command under newPassphrase, replacing
+// any existing encryption. An empty newPassphrase disables encryption,
+// decrypting every record and removing the meta bucket's header. It runs
+// as a single Bolt transaction so a crash partway through leaves the
+// database in its prior state rather than a mix of old and new keys.
+func (s *Store) Rekey(newPassphrase string) error {
+	var newCipher *crypto.Cipher
+	var kdf crypto.KDFParams
+	var check []byte
+	if newPassphrase != "" {
+		var err error
+		kdf, err = crypto.DefaultKDFParams()
+		if err != nil {
+			return err
+		}
+		newCipher, err = crypto.NewCipher(crypto.DeriveKey(newPassphrase, kdf))
+		if err != nil {
+			return err
+		}
+		check, err = newCipher.Encrypt(checkPlaintext)
+		if err != nil {
+			return err
+		}
+	}
+
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucketCommands)
+		c := b.Cursor()
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			cmd, _, err := s.decodeCommand(v)
+			if err != nil {
+				return err
+			}
+
+			oldCipher := s.cipher
+			s.cipher = newCipher
+			encoded, err := s.encodeCommand(cmd)
+			s.cipher = oldCipher
+			if err != nil {
+				return err
+			}
+			if err := b.Put(k, encoded); err != nil {
+				return err
+			}
+		}
+
+		meta := tx.Bucket(bucketMeta)
+		if newCipher == nil {
+			if err := meta.Delete(kdfParamsKey); err != nil {
+				return err
+			}
+			return meta.Delete(checkKey)
+		}
+		if err := meta.Put(kdfParamsKey, kdf.Marshal()); err != nil {
+			return err
+		}
+		return meta.Put(checkKey, check)
+	})
+	if err != nil {
+		return err
+	}
+
+	s.cipher = newCipher
+	return nil
+}
@@ -0,0 +1,383 @@
+package boltstore
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/IvanGael/Go-CommandsSaver/internal/codec"
+	"github.com/IvanGael/Go-CommandsSaver/internal/store"
+	"github.com/boltdb/bolt"
+)
+
+func openTestStore(t *testing.T) *Store {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "commands.db")
+	s, err := Open(path, "", nil)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+	return s.(*Store)
+}
+
+// indexCounts returns the number of entries in each secondary index, for
+// asserting that they stay in lockstep with the commands bucket.
+func indexCounts(t *testing.T, s *Store) (byTech, byDate int) {
+	t.Helper()
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		byTech = tx.Bucket(bucketByTechnology).Stats().KeyN
+		byDate = tx.Bucket(bucketByDate).Stats().KeyN
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("indexCounts: %v", err)
+	}
+	return byTech, byDate
+}
+
+// TestOpenBackfillsIndexesForPreexistingRecords simulates a database
+// written before the secondary indexes existed (or left with records
+// whose index entries were never created): a commands bucket entry with
+// no matching by_technology/by_date entries. Open must backfill them so
+// Tech/Since-filtered searches don't silently miss the record.
+func TestOpenBackfillsIndexesForPreexistingRecords(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "commands.db")
+
+	cmd := store.Command{
+		ID:         1,
+		Technology: "go",
+		Command:    "go build ./...",
+		Reason:     "compile",
+		DateAdded:  time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	encoded, err := codec.Encode(codec.Record{
+		ID: cmd.ID, Technology: cmd.Technology, Command: cmd.Command, Reason: cmd.Reason, DateAdded: cmd.DateAdded,
+	})
+	if err != nil {
+		t.Fatalf("codec.Encode: %v", err)
+	}
+
+	db, err := bolt.Open(path, 0600, nil)
+	if err != nil {
+		t.Fatalf("bolt.Open: %v", err)
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists(bucketCommands)
+		if err != nil {
+			return err
+		}
+		return b.Put(itob(cmd.ID), encoded)
+	})
+	if err != nil {
+		t.Fatalf("seed commands bucket: %v", err)
+	}
+	if err := db.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	s, err := Open(path, "", nil)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+
+	if byTech, byDate := indexCounts(t, s.(*Store)); byTech != 1 || byDate != 1 {
+		t.Fatalf("after Open: got byTech=%d byDate=%d, want 1 and 1", byTech, byDate)
+	}
+
+	results, err := s.Search("", store.SearchFilters{Tech: "go"})
+	if err != nil {
+		t.Fatalf("Search by tech: %v", err)
+	}
+	if len(results) != 1 {
+		t.Errorf("Search(Tech: go) = %+v, want the backfilled command", results)
+	}
+
+	results, err = s.Search("", store.SearchFilters{Since: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)})
+	if err != nil {
+		t.Fatalf("Search by since: %v", err)
+	}
+	if len(results) != 1 {
+		t.Errorf("Search(Since: 2023-01-01) = %+v, want the backfilled command", results)
+	}
+}
+
+func TestIndexConsistencyAfterAddEditDelete(t *testing.T) {
+	s := openTestStore(t)
+
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	if _, err := s.Add("go", "go build ./...", "compile", now); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if _, err := s.Add("bash", "ls -la", "list files", now.Add(time.Hour)); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	if byTech, byDate := indexCounts(t, s); byTech != 2 || byDate != 2 {
+		t.Fatalf("after adds: got byTech=%d byDate=%d, want 2 and 2", byTech, byDate)
+	}
+
+	if err := s.Update(1, "golang", "go test ./...", "run tests", now.Add(2*time.Hour)); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if byTech, byDate := indexCounts(t, s); byTech != 2 || byDate != 2 {
+		t.Fatalf("after edit: got byTech=%d byDate=%d, want 2 and 2 (stale entries left behind)", byTech, byDate)
+	}
+
+	results, err := s.Search("", store.SearchFilters{Tech: "go"})
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if len(results) != 0 {
+		t.Errorf("expected no commands left under the old technology, got %d", len(results))
+	}
+
+	results, err = s.Search("", store.SearchFilters{Tech: "golang"})
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if len(results) != 1 || results[0].Command != "go test ./..." {
+		t.Fatalf("Search(golang) = %+v, want the updated record", results)
+	}
+
+	if err := s.Delete(2); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if byTech, byDate := indexCounts(t, s); byTech != 1 || byDate != 1 {
+		t.Fatalf("after delete: got byTech=%d byDate=%d, want 1 and 1", byTech, byDate)
+	}
+
+	commands, err := s.List()
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(commands) != 1 || commands[0].ID != 1 {
+		t.Fatalf("List = %+v, want only command 1 left", commands)
+	}
+}
+
+func TestSearchQueryAndSince(t *testing.T) {
+	s := openTestStore(t)
+
+	early := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	late := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	if _, err := s.Add("go", "go vet ./...", "static analysis", early); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if _, err := s.Add("docker", "docker ps", "list containers", late); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	results, err := s.Search("vet", store.SearchFilters{})
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if len(results) != 1 || results[0].Technology != "go" {
+		t.Fatalf("Search(vet) = %+v, want the go vet command", results)
+	}
+
+	since := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	results, err = s.Search("", store.SearchFilters{Since: since})
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if len(results) != 1 || results[0].Technology != "docker" {
+		t.Fatalf("Search(since) = %+v, want only the docker command", results)
+	}
+}
+
+func TestDeleteUnknownID(t *testing.T) {
+	s := openTestStore(t)
+
+	if err := s.Delete(42); err == nil {
+		t.Error("expected an error deleting a command that doesn't exist")
+	}
+}
+
+func TestGet(t *testing.T) {
+	s := openTestStore(t)
+
+	added, err := s.Add("go", "go build ./...", "compile", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	got, err := s.Get(added.ID)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got != added {
+		t.Errorf("Get(%d) = %+v, want %+v", added.ID, got, added)
+	}
+
+	if _, err := s.Get(999); err == nil {
+		t.Error("expected an error getting a command that doesn't exist")
+	}
+}
+
+func TestImportCSV(t *testing.T) {
+	s := openTestStore(t)
+
+	// A bare "history | cmdsaver import" line (just the command) is
+	// valid, not malformed; only rows the CSV reader itself rejects
+	// (here, an unterminated quote) should count as errors.
+	input := "go,go build ./...,compile\nbare command\nbad\"row\nbash,ls -la,list files\n"
+	imported, errs := store.Import(s, strings.NewReader(input))
+
+	if imported != 3 {
+		t.Errorf("imported = %d, want 3", imported)
+	}
+	if len(errs) != 1 {
+		t.Fatalf("errs = %v, want exactly one error for the malformed row", errs)
+	}
+
+	commands, err := s.List()
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(commands) != 3 {
+		t.Fatalf("List = %+v, want 3 imported commands", commands)
+	}
+	if commands[1].Command != "bare command" || commands[1].Technology != "" {
+		t.Errorf("commands[1] = %+v, want bare command with empty technology", commands[1])
+	}
+
+	if byTech, byDate := indexCounts(t, s); byTech != 3 || byDate != 3 {
+		t.Errorf("after import: got byTech=%d byDate=%d, want 3 and 3", byTech, byDate)
+	}
+}
+
+func TestImportJSON(t *testing.T) {
+	s := openTestStore(t)
+
+	input := `[{"technology":"go","command":"go vet ./...","reason":"static analysis"}]`
+	imported, errs := store.Import(s, strings.NewReader(input))
+
+	if imported != 1 {
+		t.Errorf("imported = %d, want 1", imported)
+	}
+	if len(errs) != 0 {
+		t.Errorf("errs = %v, want none", errs)
+	}
+}
+
+func TestOpenEncryptedRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "commands.db")
+
+	s, err := Open(path, "hunter2", nil)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	added, err := s.Add("go", "go build ./...", "compile", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	reopened, err := Open(path, "hunter2", nil)
+	if err != nil {
+		t.Fatalf("Open with correct passphrase: %v", err)
+	}
+	defer reopened.Close()
+
+	got, err := reopened.Get(added.ID)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got != added {
+		t.Errorf("Get(%d) = %+v, want %+v", added.ID, got, added)
+	}
+}
+
+func TestOpenEncryptedWrongPassphraseFails(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "commands.db")
+
+	s, err := Open(path, "hunter2", nil)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	if _, err := s.Add("go", "go build ./...", "compile", time.Now()); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	if _, err := Open(path, "wrong", nil); err == nil {
+		t.Error("expected Open with the wrong passphrase to fail")
+	}
+}
+
+func TestOpenEncryptedNoPassphraseFails(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "commands.db")
+
+	s, err := Open(path, "hunter2", nil)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	if _, err := Open(path, "", nil); err == nil {
+		t.Error("expected Open without a passphrase or prompt to fail on an encrypted database")
+	}
+}
+
+func TestEnableEncryptionOnPopulatedDatabaseFails(t *testing.T) {
+	s := openTestStore(t)
+	if _, err := s.Add("go", "go build ./...", "compile", time.Now()); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	path := s.db.Path()
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	if _, err := Open(path, "hunter2", nil); err == nil {
+		t.Error("expected enabling encryption on a populated plaintext database to fail")
+	}
+}
+
+func TestRekey(t *testing.T) {
+	s := openTestStore(t)
+	added, err := s.Add("go", "go build ./...", "compile", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	if err := s.Rekey("hunter2"); err != nil {
+		t.Fatalf("Rekey (enable): %v", err)
+	}
+	got, err := s.Get(added.ID)
+	if err != nil {
+		t.Fatalf("Get after Rekey: %v", err)
+	}
+	if got != added {
+		t.Errorf("Get after Rekey = %+v, want %+v", got, added)
+	}
+
+	if err := s.Rekey("new-passphrase"); err != nil {
+		t.Fatalf("Rekey (rotate): %v", err)
+	}
+	if got, err := s.Get(added.ID); err != nil || got != added {
+		t.Errorf("Get after rotating Rekey = %+v, %v, want %+v, nil", got, err, added)
+	}
+
+	if err := s.Rekey(""); err != nil {
+		t.Fatalf("Rekey (disable): %v", err)
+	}
+	if got, err := s.Get(added.ID); err != nil || got != added {
+		t.Errorf("Get after disabling Rekey = %+v, %v, want %+v, nil", got, err, added)
+	}
+	if s.cipher != nil {
+		t.Error("expected cipher to be nil after disabling encryption")
+	}
+}
@@ -0,0 +1,77 @@
+package boltstore
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/IvanGael/Go-CommandsSaver/internal/store"
+	"github.com/boltdb/bolt"
+)
+
+// ImportRows inserts rows in groups of store.ImportBatchSize, each group
+// in its own Bolt transaction, implementing store.BatchImporter.
+func (s *Store) ImportRows(rows []store.ImportRow) (int, []error) {
+	var (
+		imported int
+		errs     []error
+	)
+	for len(rows) > 0 {
+		n := store.ImportBatchSize
+		if n > len(rows) {
+			n = len(rows)
+		}
+		batch := rows[:n]
+		rows = rows[n:]
+
+		count, batchErrs := s.insertRowBatch(batch)
+		imported += count
+		errs = append(errs, batchErrs...)
+	}
+	return imported, errs
+}
+
+// insertRowBatch inserts a single batch of rows inside one Bolt
+// transaction. A row that fails to encode or persist is recorded as an
+// error and skipped; it does not roll back the rows already committed
+// earlier in the same transaction.
+func (s *Store) insertRowBatch(rows []store.ImportRow) (int, []error) {
+	var (
+		imported int
+		errs     []error
+	)
+	now := time.Now()
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucketCommands)
+		for i, row := range rows {
+			id, _ := b.NextSequence()
+			cmd := store.Command{
+				ID:         int(id),
+				Technology: row.Technology,
+				Command:    row.Command,
+				Reason:     row.Reason,
+				DateAdded:  now,
+			}
+			encoded, err := s.encodeCommand(cmd)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("row %d: %w", i, err))
+				continue
+			}
+			if err := b.Put(itob(cmd.ID), encoded); err != nil {
+				errs = append(errs, fmt.Errorf("row %d: %w", i, err))
+				continue
+			}
+			if err := putIndexes(tx, cmd); err != nil {
+				errs = append(errs, fmt.Errorf("row %d: %w", i, err))
+				continue
+			}
+			imported++
+		}
+		return nil
+	})
+	if err != nil {
+		// The transaction did not commit, so none of its rows were stored.
+		errs = append(errs, err)
+		imported = 0
+	}
+	return imported, errs
+}
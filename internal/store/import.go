@@ -0,0 +1,121 @@
+package store
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// ImportBatchSize bounds how many records a BatchImporter should write
+// per transaction when importing, so a large stdin stream doesn't grow a
+// single transaction unboundedly.
+const ImportBatchSize = 200
+
+// ImportRow is a single record read from an import source, before it is
+// assigned an ID and a timestamp.
+type ImportRow struct {
+	Technology string `json:"technology"`
+	Command    string `json:"command"`
+	Reason     string `json:"reason"`
+}
+
+// BatchImporter is implemented by Store backends that can bulk-insert
+// parsed rows inside bounded transactions. Import falls back to
+// repeated Add calls for a backend that doesn't implement it.
+type BatchImporter interface {
+	ImportRows(rows []ImportRow) (int, []error)
+}
+
+// Import reads commands from r (CSV rows of "technology,command,reason",
+// a bare command per line, or a JSON array of the same fields) and
+// inserts them into s, batching
+// the writes when s implements BatchImporter. It returns the number of
+// rows imported and any per-row errors encountered along the way; a
+// malformed CSV row is reported but never aborts the rest of the import.
+// JSON input is decoded as one array, so a malformed array or element
+// is reported as a single error and none of its rows are imported.
+func Import(s Store, r io.Reader) (int, []error) {
+	rows, errs := parseImportRows(r)
+	if len(rows) == 0 {
+		return 0, errs
+	}
+
+	if bi, ok := s.(BatchImporter); ok {
+		n, batchErrs := bi.ImportRows(rows)
+		return n, append(errs, batchErrs...)
+	}
+
+	var imported int
+	for _, row := range rows {
+		if _, err := s.Add(row.Technology, row.Command, row.Reason, time.Now()); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		imported++
+	}
+	return imported, errs
+}
+
+func parseImportRows(r io.Reader) ([]ImportRow, []error) {
+	br := bufio.NewReader(r)
+
+	first, err := br.ReadByte()
+	if err != nil {
+		if err == io.EOF {
+			return nil, nil
+		}
+		return nil, []error{err}
+	}
+	br.UnreadByte()
+
+	if first == '[' {
+		return parseJSONRows(br)
+	}
+	return parseCSVRows(br)
+}
+
+func parseJSONRows(r io.Reader) ([]ImportRow, []error) {
+	var rows []ImportRow
+	if err := json.NewDecoder(r).Decode(&rows); err != nil {
+		return nil, []error{fmt.Errorf("decode json: %w", err)}
+	}
+	return rows, nil
+}
+
+func parseCSVRows(r io.Reader) ([]ImportRow, []error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	var (
+		rows    []ImportRow
+		errs    []error
+		lineNum int
+	)
+	for {
+		lineNum++
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("line %d: %w", lineNum, err))
+			continue
+		}
+		var row ImportRow
+		switch {
+		case len(record) == 1:
+			// A bare "one command per line" stream, e.g. `history | cmdsaver import`.
+			row.Command = record[0]
+		case len(record) >= 2:
+			row.Technology, row.Command = record[0], record[1]
+			if len(record) > 2 {
+				row.Reason = record[2]
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, errs
+}
@@ -0,0 +1,365 @@
+// Package leveldbstore persists store.Commands in a LevelDB database
+// (via github.com/syndtr/goleveldb) behind the store.Store interface,
+// for users who want to share a database with other LevelDB-based
+// tooling. It does not support the at-rest encryption boltstore offers.
+package leveldbstore
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/IvanGael/Go-CommandsSaver/internal/codec"
+	"github.com/IvanGael/Go-CommandsSaver/internal/store"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// Key prefixes. Unlike BoltDB there are no buckets, so commands and
+// their secondary indexes live in the same keyspace under distinct
+// prefixes. by_technology and by_date mirror boltstore's indexes so
+// Search can seek a narrow key range instead of scanning every command.
+var (
+	prefixCommand      = []byte("cmd\x00")
+	prefixByTechnology = []byte("idx_tech\x00")
+	prefixByDate       = []byte("idx_date\x00")
+	seqKey             = []byte("seq")
+)
+
+// Store is the LevelDB-backed store.Store implementation. mu serializes
+// the read-modify-write sequence of the id counter and the batched
+// writes derived from it, since goleveldb batches are atomic but not
+// mutually exclusive on their own.
+type Store struct {
+	db *leveldb.DB
+	mu sync.Mutex
+}
+
+// Open opens (creating if necessary) a LevelDB database at path.
+func Open(path string) (store.Store, error) {
+	db, err := leveldb.OpenFile(path, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &Store{db: db}, nil
+}
+
+// Close releases the underlying database handle.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Add adds a new command to the database.
+func (s *Store) Add(technology, command, reason string, date time.Time) (store.Command, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id, err := s.nextSequence()
+	if err != nil {
+		return store.Command{}, err
+	}
+	cmd := store.Command{ID: id, Technology: technology, Command: command, Reason: reason, DateAdded: date}
+
+	encoded, err := encodeCommand(cmd)
+	if err != nil {
+		return store.Command{}, err
+	}
+
+	batch := new(leveldb.Batch)
+	batch.Put(commandKey(id), encoded)
+	putIndexes(batch, cmd)
+	if err := s.db.Write(batch, nil); err != nil {
+		return store.Command{}, err
+	}
+	return cmd, nil
+}
+
+// Get retrieves a single command by ID.
+func (s *Store) Get(id int) (store.Command, error) {
+	cmd, found, err := s.getCommand(id)
+	if err != nil {
+		return store.Command{}, err
+	}
+	if !found {
+		return store.Command{}, fmt.Errorf("no command with id %d", id)
+	}
+	return cmd, nil
+}
+
+// getCommand looks up a command without translating a missing record
+// into an error, so Search can silently skip a stale index entry the
+// way boltstore's Search skips an ID whose record is missing.
+func (s *Store) getCommand(id int) (store.Command, bool, error) {
+	data, err := s.db.Get(commandKey(id), nil)
+	if err != nil {
+		if err == leveldb.ErrNotFound {
+			return store.Command{}, false, nil
+		}
+		return store.Command{}, false, err
+	}
+	cmd, _, err := decodeCommand(data)
+	if err != nil {
+		return store.Command{}, false, err
+	}
+	return cmd, true, nil
+}
+
+// List retrieves all commands from the database.
+func (s *Store) List() ([]store.Command, error) {
+	iter := s.db.NewIterator(util.BytesPrefix(prefixCommand), nil)
+	defer iter.Release()
+
+	var commands []store.Command
+	for iter.Next() {
+		cmd, _, err := decodeCommand(iter.Value())
+		if err != nil {
+			return nil, err
+		}
+		commands = append(commands, cmd)
+	}
+	return commands, iter.Error()
+}
+
+// Delete removes a command and its secondary index entries.
+func (s *Store) Delete(id int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cmd, err := s.Get(id)
+	if err != nil {
+		return err
+	}
+
+	batch := new(leveldb.Batch)
+	batch.Delete(commandKey(id))
+	deleteIndexes(batch, cmd)
+	return s.db.Write(batch, nil)
+}
+
+// Update replaces the technology, command, reason, and date of an
+// existing command, keeping the secondary indexes in sync with the new
+// values.
+func (s *Store) Update(id int, technology, command, reason string, date time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	old, err := s.Get(id)
+	if err != nil {
+		return err
+	}
+	updated := store.Command{ID: id, Technology: technology, Command: command, Reason: reason, DateAdded: date}
+
+	encoded, err := encodeCommand(updated)
+	if err != nil {
+		return err
+	}
+
+	batch := new(leveldb.Batch)
+	batch.Put(commandKey(id), encoded)
+	deleteIndexes(batch, old)
+	putIndexes(batch, updated)
+	return s.db.Write(batch, nil)
+}
+
+// Search returns the commands whose technology, command, or reason
+// contains query (case-insensitively), narrowed by filters. A non-empty
+// Tech or Since filter is served by a secondary index instead of a full
+// scan of the commands.
+func (s *Store) Search(query string, filters store.SearchFilters) ([]store.Command, error) {
+	ids, err := s.candidateIDs(filters)
+	if err != nil {
+		return nil, err
+	}
+
+	query = strings.ToLower(query)
+	var results []store.Command
+	for _, id := range ids {
+		cmd, found, err := s.getCommand(id)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			continue
+		}
+		if !matchesFilters(cmd, filters) {
+			continue
+		}
+		if query != "" && !containsQuery(cmd, query) {
+			continue
+		}
+		results = append(results, cmd)
+	}
+	return results, nil
+}
+
+func (s *Store) candidateIDs(filters store.SearchFilters) ([]int, error) {
+	switch {
+	case filters.Tech != "" && !filters.Since.IsZero():
+		byTech := idSet(s.idsByTechnology(filters.Tech))
+		var ids []int
+		for _, id := range s.idsSince(filters.Since) {
+			if byTech[id] {
+				ids = append(ids, id)
+			}
+		}
+		return ids, nil
+	case filters.Tech != "":
+		return s.idsByTechnology(filters.Tech), nil
+	case !filters.Since.IsZero():
+		return s.idsSince(filters.Since), nil
+	default:
+		return s.allIDs()
+	}
+}
+
+func (s *Store) allIDs() ([]int, error) {
+	iter := s.db.NewIterator(util.BytesPrefix(prefixCommand), nil)
+	defer iter.Release()
+
+	var ids []int
+	for iter.Next() {
+		ids = append(ids, int(binary.BigEndian.Uint64(iter.Key()[len(prefixCommand):])))
+	}
+	return ids, iter.Error()
+}
+
+func (s *Store) idsByTechnology(technology string) []int {
+	prefix := technologyIndexPrefix(technology)
+	iter := s.db.NewIterator(util.BytesPrefix(prefix), nil)
+	defer iter.Release()
+
+	var ids []int
+	for iter.Next() {
+		ids = append(ids, int(binary.BigEndian.Uint64(iter.Value())))
+	}
+	return ids
+}
+
+func (s *Store) idsSince(since time.Time) []int {
+	r := &util.Range{Start: dateIndexSeekKey(since), Limit: util.BytesPrefix(prefixByDate).Limit}
+	iter := s.db.NewIterator(r, nil)
+	defer iter.Release()
+
+	var ids []int
+	for iter.Next() {
+		ids = append(ids, int(binary.BigEndian.Uint64(iter.Value())))
+	}
+	return ids
+}
+
+func idSet(ids []int) map[int]bool {
+	set := make(map[int]bool, len(ids))
+	for _, id := range ids {
+		set[id] = true
+	}
+	return set
+}
+
+func matchesFilters(cmd store.Command, filters store.SearchFilters) bool {
+	if filters.Tech != "" && cmd.Technology != filters.Tech {
+		return false
+	}
+	if !filters.Since.IsZero() && cmd.DateAdded.Before(filters.Since) {
+		return false
+	}
+	return true
+}
+
+func containsQuery(cmd store.Command, lowerQuery string) bool {
+	return strings.Contains(strings.ToLower(cmd.Technology), lowerQuery) ||
+		strings.Contains(strings.ToLower(cmd.Command), lowerQuery) ||
+		strings.Contains(strings.ToLower(cmd.Reason), lowerQuery)
+}
+
+// nextSequence returns the next command ID and persists the updated
+// counter. Callers must hold s.mu.
+func (s *Store) nextSequence() (int, error) {
+	data, err := s.db.Get(seqKey, nil)
+	if err != nil && err != leveldb.ErrNotFound {
+		return 0, err
+	}
+
+	var next uint64 = 1
+	if err == nil {
+		next = binary.BigEndian.Uint64(data) + 1
+	}
+
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint64(buf, next)
+	if err := s.db.Put(seqKey, buf, nil); err != nil {
+		return 0, err
+	}
+	return int(next), nil
+}
+
+func putIndexes(batch *leveldb.Batch, cmd store.Command) {
+	batch.Put(technologyIndexKey(cmd.Technology, cmd.ID), itob(cmd.ID))
+	batch.Put(dateIndexKey(cmd.DateAdded, cmd.ID), itob(cmd.ID))
+}
+
+func deleteIndexes(batch *leveldb.Batch, cmd store.Command) {
+	batch.Delete(technologyIndexKey(cmd.Technology, cmd.ID))
+	batch.Delete(dateIndexKey(cmd.DateAdded, cmd.ID))
+}
+
+func commandKey(id int) []byte {
+	return append(append([]byte{}, prefixCommand...), itob(id)...)
+}
+
+func technologyIndexPrefix(technology string) []byte {
+	key := append(append([]byte{}, prefixByTechnology...), []byte(technology)...)
+	return append(key, 0x00)
+}
+
+func technologyIndexKey(technology string, id int) []byte {
+	return append(technologyIndexPrefix(technology), itob(id)...)
+}
+
+func dateIndexKey(t time.Time, id int) []byte {
+	key := append(append([]byte{}, prefixByDate...), make([]byte, 16)...)
+	binary.BigEndian.PutUint64(key[len(prefixByDate):len(prefixByDate)+8], uint64(t.UnixNano()))
+	binary.BigEndian.PutUint64(key[len(prefixByDate)+8:], uint64(id))
+	return key
+}
+
+func dateIndexSeekKey(since time.Time) []byte {
+	key := append(append([]byte{}, prefixByDate...), make([]byte, 8)...)
+	binary.BigEndian.PutUint64(key[len(prefixByDate):], uint64(since.UnixNano()))
+	return key
+}
+
+func itob(v int) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, uint64(v))
+	return b
+}
+
+func encodeCommand(cmd store.Command) ([]byte, error) {
+	return codec.Encode(codec.Record{
+		ID:         cmd.ID,
+		Technology: cmd.Technology,
+		Command:    cmd.Command,
+		Reason:     cmd.Reason,
+		DateAdded:  cmd.DateAdded,
+	})
+}
+
+// decodeCommand decodes a byte slice into a command, transparently
+// supporting records stored under the legacy comma-joined format. The
+// returned bool reports whether the record was read via that legacy
+// path, matching boltstore's decodeCommand.
+func decodeCommand(data []byte) (store.Command, bool, error) {
+	rec, legacy, err := codec.Decode(data)
+	if err != nil {
+		return store.Command{}, false, err
+	}
+	return store.Command{
+		ID:         rec.ID,
+		Technology: rec.Technology,
+		Command:    rec.Command,
+		Reason:     rec.Reason,
+		DateAdded:  rec.DateAdded,
+	}, legacy, nil
+}
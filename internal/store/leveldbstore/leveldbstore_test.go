@@ -0,0 +1,157 @@
+package leveldbstore
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/IvanGael/Go-CommandsSaver/internal/store"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+func openTestStore(t *testing.T) *Store {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "commands.ldb")
+	s, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+	return s.(*Store)
+}
+
+// indexCounts returns the number of entries under the by_technology and
+// by_date key prefixes, for asserting that they stay in lockstep with
+// the commands keyspace.
+func indexCounts(t *testing.T, s *Store) (byTech, byDate int) {
+	t.Helper()
+
+	techIter := s.db.NewIterator(util.BytesPrefix(prefixByTechnology), nil)
+	defer techIter.Release()
+	for techIter.Next() {
+		byTech++
+	}
+
+	dateIter := s.db.NewIterator(util.BytesPrefix(prefixByDate), nil)
+	defer dateIter.Release()
+	for dateIter.Next() {
+		byDate++
+	}
+
+	return byTech, byDate
+}
+
+func TestIndexConsistencyAfterAddEditDelete(t *testing.T) {
+	s := openTestStore(t)
+
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	if _, err := s.Add("go", "go build ./...", "compile", now); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if _, err := s.Add("bash", "ls -la", "list files", now.Add(time.Hour)); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	if byTech, byDate := indexCounts(t, s); byTech != 2 || byDate != 2 {
+		t.Fatalf("after adds: got byTech=%d byDate=%d, want 2 and 2", byTech, byDate)
+	}
+
+	if err := s.Update(1, "golang", "go test ./...", "run tests", now.Add(2*time.Hour)); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if byTech, byDate := indexCounts(t, s); byTech != 2 || byDate != 2 {
+		t.Fatalf("after edit: got byTech=%d byDate=%d, want 2 and 2 (stale entries left behind)", byTech, byDate)
+	}
+
+	results, err := s.Search("", store.SearchFilters{Tech: "go"})
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if len(results) != 0 {
+		t.Errorf("expected no commands left under the old technology, got %d", len(results))
+	}
+
+	results, err = s.Search("", store.SearchFilters{Tech: "golang"})
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if len(results) != 1 || results[0].Command != "go test ./..." {
+		t.Fatalf("Search(golang) = %+v, want the updated record", results)
+	}
+
+	if err := s.Delete(2); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if byTech, byDate := indexCounts(t, s); byTech != 1 || byDate != 1 {
+		t.Fatalf("after delete: got byTech=%d byDate=%d, want 1 and 1", byTech, byDate)
+	}
+
+	commands, err := s.List()
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(commands) != 1 || commands[0].ID != 1 {
+		t.Fatalf("List = %+v, want only command 1 left", commands)
+	}
+}
+
+func TestSearchQueryAndSince(t *testing.T) {
+	s := openTestStore(t)
+
+	early := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	late := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	if _, err := s.Add("go", "go vet ./...", "static analysis", early); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if _, err := s.Add("docker", "docker ps", "list containers", late); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	results, err := s.Search("vet", store.SearchFilters{})
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if len(results) != 1 || results[0].Technology != "go" {
+		t.Fatalf("Search(vet) = %+v, want the go vet command", results)
+	}
+
+	since := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	results, err = s.Search("", store.SearchFilters{Since: since})
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if len(results) != 1 || results[0].Technology != "docker" {
+		t.Fatalf("Search(since) = %+v, want only the docker command", results)
+	}
+}
+
+func TestDeleteUnknownID(t *testing.T) {
+	s := openTestStore(t)
+
+	if err := s.Delete(42); err == nil {
+		t.Error("expected an error deleting a command that doesn't exist")
+	}
+}
+
+func TestGet(t *testing.T) {
+	s := openTestStore(t)
+
+	added, err := s.Add("go", "go build ./...", "compile", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	got, err := s.Get(added.ID)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got != added {
+		t.Errorf("Get(%d) = %+v, want %+v", added.ID, got, added)
+	}
+
+	if _, err := s.Get(999); err == nil {
+		t.Error("expected an error getting a command that doesn't exist")
+	}
+}
@@ -0,0 +1,26 @@
+package store
+
+// Migrate copies every record in src into dst via Add, preserving each
+// record's technology, command, reason, and date but not its ID (the
+// destination backend assigns its own). It returns the number of
+// records copied and any per-record errors encountered; a record that
+// fails to insert is reported but doesn't abort the rest of the copy.
+func Migrate(src, dst Store) (int, []error) {
+	commands, err := src.List()
+	if err != nil {
+		return 0, []error{err}
+	}
+
+	var (
+		migrated int
+		errs     []error
+	)
+	for _, cmd := range commands {
+		if _, err := dst.Add(cmd.Technology, cmd.Command, cmd.Reason, cmd.DateAdded); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		migrated++
+	}
+	return migrated, errs
+}
@@ -0,0 +1,204 @@
+// Package sqlitestore persists store.Commands in a SQLite database (via
+// modernc.org/sqlite, a cgo-free driver) behind the store.Store
+// interface. It does not support the at-rest encryption boltstore
+// offers.
+//
+// Search deliberately gives up FTS5 full-text ranking in favor of the
+// same plain substring match the bolt and leveldb backends use, so
+// `cmdsaver search` behaves identically regardless of --backend.
+package sqlitestore
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/IvanGael/Go-CommandsSaver/internal/store"
+	_ "modernc.org/sqlite"
+)
+
+const schema = `
+CREATE TABLE IF NOT EXISTS commands (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	technology TEXT NOT NULL,
+	command TEXT NOT NULL,
+	reason TEXT NOT NULL,
+	date_added INTEGER NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_commands_technology ON commands(technology);
+CREATE INDEX IF NOT EXISTS idx_commands_date_added ON commands(date_added);
+`
+
+// Store is the SQLite-backed store.Store implementation.
+type Store struct {
+	db *sql.DB
+}
+
+// Open opens (creating if necessary) a SQLite database at path and
+// ensures the commands table and its indexes exist.
+func Open(path string) (store.Store, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("sqlitestore: create schema: %w", err)
+	}
+	return &Store{db: db}, nil
+}
+
+// Close releases the underlying database handle.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Add adds a new command to the database.
+func (s *Store) Add(technology, command, reason string, date time.Time) (store.Command, error) {
+	res, err := s.db.Exec(
+		`INSERT INTO commands (technology, command, reason, date_added) VALUES (?, ?, ?, ?)`,
+		technology, command, reason, date.UnixNano(),
+	)
+	if err != nil {
+		return store.Command{}, err
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return store.Command{}, err
+	}
+	return store.Command{ID: int(id), Technology: technology, Command: command, Reason: reason, DateAdded: date}, nil
+}
+
+// Get retrieves a single command by ID.
+func (s *Store) Get(id int) (store.Command, error) {
+	row := s.db.QueryRow(
+		`SELECT id, technology, command, reason, date_added FROM commands WHERE id = ?`, id,
+	)
+	cmd, err := scanCommand(row)
+	if err == sql.ErrNoRows {
+		return store.Command{}, fmt.Errorf("no command with id %d", id)
+	}
+	return cmd, err
+}
+
+// List retrieves all commands from the database.
+func (s *Store) List() ([]store.Command, error) {
+	rows, err := s.db.Query(`SELECT id, technology, command, reason, date_added FROM commands ORDER BY id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanCommands(rows)
+}
+
+// Delete removes a command by ID.
+func (s *Store) Delete(id int) error {
+	res, err := s.db.Exec(`DELETE FROM commands WHERE id = ?`, id)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return fmt.Errorf("no command with id %d", id)
+	}
+	return nil
+}
+
+// Update replaces the technology, command, reason, and date of an
+// existing command.
+func (s *Store) Update(id int, technology, command, reason string, date time.Time) error {
+	res, err := s.db.Exec(
+		`UPDATE commands SET technology = ?, command = ?, reason = ?, date_added = ? WHERE id = ?`,
+		technology, command, reason, date.UnixNano(), id,
+	)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return fmt.Errorf("no command with id %d", id)
+	}
+	return nil
+}
+
+// Search returns the commands matching query and filters. A non-empty
+// query is a case-insensitive substring match over technology, command,
+// and reason, the same semantics the bolt and leveldb backends use, so
+// results don't depend on which --backend is selected. Tech and Since
+// narrow the result with plain WHERE clauses, which SQLite serves from
+// idx_commands_technology and idx_commands_date_added.
+func (s *Store) Search(query string, filters store.SearchFilters) ([]store.Command, error) {
+	var (
+		where []string
+		args  []any
+	)
+
+	if strings.TrimSpace(query) != "" {
+		like := "%" + escapeLike(strings.ToLower(query)) + "%"
+		where = append(where, `(lower(technology) LIKE ? ESCAPE '\' OR lower(command) LIKE ? ESCAPE '\' OR lower(reason) LIKE ? ESCAPE '\')`)
+		args = append(args, like, like, like)
+	}
+	if filters.Tech != "" {
+		where = append(where, `technology = ?`)
+		args = append(args, filters.Tech)
+	}
+	if !filters.Since.IsZero() {
+		where = append(where, `date_added >= ?`)
+		args = append(args, filters.Since.UnixNano())
+	}
+
+	sqlQuery := `SELECT id, technology, command, reason, date_added FROM commands`
+	if len(where) > 0 {
+		sqlQuery += " WHERE " + strings.Join(where, " AND ")
+	}
+	sqlQuery += " ORDER BY id"
+
+	rows, err := s.db.Query(sqlQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanCommands(rows)
+}
+
+// escapeLike backslash-escapes the LIKE wildcard characters % and _ (and
+// a literal backslash) so a query containing them is matched as literal
+// text rather than a wildcard, matching the literal substring semantics
+// strings.Contains gives the bolt and leveldb backends.
+func escapeLike(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	return r.Replace(s)
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanCommand(row rowScanner) (store.Command, error) {
+	var cmd store.Command
+	var dateAdded int64
+	if err := row.Scan(&cmd.ID, &cmd.Technology, &cmd.Command, &cmd.Reason, &dateAdded); err != nil {
+		return store.Command{}, err
+	}
+	cmd.DateAdded = time.Unix(0, dateAdded).UTC()
+	return cmd, nil
+}
+
+func scanCommands(rows *sql.Rows) ([]store.Command, error) {
+	var commands []store.Command
+	for rows.Next() {
+		cmd, err := scanCommand(rows)
+		if err != nil {
+			return nil, err
+		}
+		commands = append(commands, cmd)
+	}
+	return commands, rows.Err()
+}
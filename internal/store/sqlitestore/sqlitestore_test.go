@@ -0,0 +1,187 @@
+package sqlitestore
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/IvanGael/Go-CommandsSaver/internal/store"
+)
+
+func openTestStore(t *testing.T) *Store {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "commands.db")
+	s, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+	return s.(*Store)
+}
+
+func TestIndexConsistencyAfterAddEditDelete(t *testing.T) {
+	s := openTestStore(t)
+
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	if _, err := s.Add("go", "go build ./...", "compile", now); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if _, err := s.Add("bash", "ls -la", "list files", now.Add(time.Hour)); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	if err := s.Update(1, "golang", "go test ./...", "run tests", now.Add(2*time.Hour)); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	results, err := s.Search("", store.SearchFilters{Tech: "go"})
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if len(results) != 0 {
+		t.Errorf("expected no commands left under the old technology, got %d", len(results))
+	}
+
+	results, err = s.Search("", store.SearchFilters{Tech: "golang"})
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if len(results) != 1 || results[0].Command != "go test ./..." {
+		t.Fatalf("Search(golang) = %+v, want the updated record", results)
+	}
+
+	if err := s.Delete(2); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+
+	commands, err := s.List()
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(commands) != 1 || commands[0].ID != 1 {
+		t.Fatalf("List = %+v, want only command 1 left", commands)
+	}
+}
+
+func TestSearchQueryAndSince(t *testing.T) {
+	s := openTestStore(t)
+
+	early := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	late := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	if _, err := s.Add("go", "go vet ./...", "static analysis", early); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if _, err := s.Add("docker", "docker ps", "list containers", late); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	// Substring, not token, match: "ubectl" only matches if ordinary
+	// substring semantics (not FTS5 token matching) are used.
+	if _, err := s.Add("k8s", "kubectl get pods", "list pods", late); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	results, err := s.Search("ubectl", store.SearchFilters{})
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if len(results) != 1 || results[0].Technology != "k8s" {
+		t.Fatalf("Search(ubectl) = %+v, want the kubectl command via substring match", results)
+	}
+
+	// A query matching the technology column, not command or reason.
+	results, err = s.Search("docker", store.SearchFilters{})
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if len(results) != 1 || results[0].Command != "docker ps" {
+		t.Fatalf("Search(docker) = %+v, want the match via the technology column", results)
+	}
+
+	results, err = s.Search("vet", store.SearchFilters{})
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if len(results) != 1 || results[0].Technology != "go" {
+		t.Fatalf("Search(vet) = %+v, want the go vet command", results)
+	}
+
+	since := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	results, err = s.Search("", store.SearchFilters{Since: since})
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("Search(since) = %+v, want the two later commands", results)
+	}
+}
+
+func TestSearchEscapesLikeWildcards(t *testing.T) {
+	s := openTestStore(t)
+
+	if _, err := s.Add("go", "go test -run Test_%", "run a test pattern", time.Now()); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if _, err := s.Add("bash", "echo hello world", "greet", time.Now()); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	results, err := s.Search("_%", store.SearchFilters{})
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if len(results) != 1 || results[0].Technology != "go" {
+		t.Fatalf("Search(_%%) = %+v, want only the command containing a literal \"_%%\"", results)
+	}
+}
+
+func TestDateAddedIsUTC(t *testing.T) {
+	s := openTestStore(t)
+
+	date := time.Date(2024, 1, 1, 12, 0, 0, 0, time.FixedZone("UTC-5", -5*60*60))
+	added, err := s.Add("go", "go build ./...", "compile", date)
+	if err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	got, err := s.Get(added.ID)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.DateAdded.Location() != time.UTC {
+		t.Errorf("DateAdded.Location() = %v, want UTC", got.DateAdded.Location())
+	}
+	if !got.DateAdded.Equal(date) {
+		t.Errorf("DateAdded = %v, want the same instant as %v", got.DateAdded, date)
+	}
+}
+
+func TestDeleteUnknownID(t *testing.T) {
+	s := openTestStore(t)
+
+	if err := s.Delete(42); err == nil {
+		t.Error("expected an error deleting a command that doesn't exist")
+	}
+}
+
+func TestGet(t *testing.T) {
+	s := openTestStore(t)
+
+	added, err := s.Add("go", "go build ./...", "compile", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	got, err := s.Get(added.ID)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got != added {
+		t.Errorf("Get(%d) = %+v, want %+v", added.ID, got, added)
+	}
+
+	if _, err := s.Get(999); err == nil {
+		t.Error("expected an error getting a command that doesn't exist")
+	}
+}
@@ -0,0 +1,45 @@
+// Package store defines the Command model and the Store interface
+// implemented by each storage backend (boltstore, leveldbstore,
+// sqlitestore), plus backend-agnostic helpers (Import, Migrate) built
+// purely on top of that interface. Callers depend only on this package
+// and internal/backend for opening a concrete Store; they never touch a
+// backend's underlying driver directly.
+package store
+
+import "time"
+
+// Command represents a command with its technology group, reason, and date added.
+type Command struct {
+	ID         int
+	Technology string
+	Command    string
+	Reason     string
+	DateAdded  time.Time
+}
+
+// SearchFilters narrows a Search query to a technology and/or a minimum
+// date. Backends are expected to serve these from an index rather than a
+// full scan where they can.
+type SearchFilters struct {
+	Tech  string
+	Since time.Time
+}
+
+// Store is the set of operations the rest of the program needs from a
+// command database, independent of how or where it's stored.
+type Store interface {
+	Add(technology, command, reason string, date time.Time) (Command, error)
+	Get(id int) (Command, error)
+	List() ([]Command, error)
+	Delete(id int) error
+	Update(id int, technology, command, reason string, date time.Time) error
+	Search(query string, filters SearchFilters) ([]Command, error)
+	Close() error
+}
+
+// Rekeyer is implemented by Store backends that can change or remove the
+// passphrase protecting their at-rest encryption. It is kept separate
+// from Store since not every backend supports encryption.
+type Rekeyer interface {
+	Rekey(newPassphrase string) error
+}
@@ -0,0 +1,97 @@
+package store
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeStore is a minimal in-memory Store that does not implement
+// BatchImporter, used to exercise Import's per-row fallback path.
+type fakeStore struct {
+	commands []Command
+	nextID   int
+}
+
+func (f *fakeStore) Add(technology, command, reason string, date time.Time) (Command, error) {
+	f.nextID++
+	cmd := Command{ID: f.nextID, Technology: technology, Command: command, Reason: reason, DateAdded: date}
+	f.commands = append(f.commands, cmd)
+	return cmd, nil
+}
+func (f *fakeStore) Get(id int) (Command, error) {
+	for _, c := range f.commands {
+		if c.ID == id {
+			return c, nil
+		}
+	}
+	return Command{}, fmt.Errorf("no command with id %d", id)
+}
+func (f *fakeStore) List() ([]Command, error) { return f.commands, nil }
+func (f *fakeStore) Delete(id int) error      { return nil }
+func (f *fakeStore) Update(id int, technology, command, reason string, date time.Time) error {
+	return nil
+}
+func (f *fakeStore) Search(query string, filters SearchFilters) ([]Command, error) { return nil, nil }
+func (f *fakeStore) Close() error                                                  { return nil }
+
+func TestImportFallsBackWithoutBatchImporter(t *testing.T) {
+	f := &fakeStore{}
+
+	imported, errs := Import(f, strings.NewReader("go,go build ./...,compile\n"))
+	if imported != 1 {
+		t.Errorf("imported = %d, want 1", imported)
+	}
+	if len(errs) != 0 {
+		t.Errorf("errs = %v, want none", errs)
+	}
+	if len(f.commands) != 1 {
+		t.Fatalf("fakeStore.commands = %+v, want 1 added command", f.commands)
+	}
+}
+
+func TestImportAcceptsBareCommandPerLine(t *testing.T) {
+	f := &fakeStore{}
+
+	imported, errs := Import(f, strings.NewReader("go build ./...\nls -la\n"))
+	if imported != 2 {
+		t.Errorf("imported = %d, want 2", imported)
+	}
+	if len(errs) != 0 {
+		t.Errorf("errs = %v, want none", errs)
+	}
+	if len(f.commands) != 2 || f.commands[0].Command != "go build ./..." || f.commands[0].Technology != "" {
+		t.Fatalf("fakeStore.commands = %+v, want bare commands with empty technology", f.commands)
+	}
+}
+
+func TestMigrateCopiesAllRecords(t *testing.T) {
+	src := &fakeStore{}
+	if _, err := src.Add("go", "go build ./...", "compile", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if _, err := src.Add("bash", "ls -la", "list files", time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	dst := &fakeStore{}
+	migrated, errs := Migrate(src, dst)
+	if migrated != 2 {
+		t.Errorf("migrated = %d, want 2", migrated)
+	}
+	if len(errs) != 0 {
+		t.Errorf("errs = %v, want none", errs)
+	}
+
+	commands, err := dst.List()
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(commands) != 2 {
+		t.Fatalf("dst.List() = %+v, want 2 migrated commands", commands)
+	}
+	if commands[0].Technology != "go" || commands[1].Technology != "bash" {
+		t.Errorf("dst.List() = %+v, want technologies preserved in order", commands)
+	}
+}